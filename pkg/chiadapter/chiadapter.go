@@ -0,0 +1,81 @@
+// Package chiadapter registers the routers and the documentation endpoints on a chi router
+package chiadapter
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/pkg/errors"
+)
+
+// New builds the openapi document of the routers then registers the routers and the documentation on routes
+func New(
+	routes chi.Router,
+	title, description, version string,
+	routers []*router.Router,
+	options ...swagger.Option,
+) (*swagger.Swagger, error) {
+	swag, err := swagger.New(title, description, version, routers, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := Register(routes, swag); err != nil {
+		return nil, err
+	}
+
+	return swag, nil
+}
+
+// Register adds the handler of each router then mounts the documentation endpoints.
+// The paths use the chi syntax, e.g. /users/{id} or /users/{id:[0-9]+}.
+func Register(routes chi.Router, swag *swagger.Swagger) error {
+	// the handlers are resolved first so an unsupported one doesn't leave the routes half registered
+	handlers := make([]http.Handler, len(swag.Routers))
+	for i, route := range swag.Routers {
+		handler, ok := router.HTTPHandler(route.Handler)
+		if !ok {
+			return errors.Wrapf(
+				errors.Wrap(router.ErrUnsupportedHandler, "chi expects a net/http handler"),
+				"%s %s: got %T", strings.ToUpper(route.Method), route.Path, route.Handler,
+			)
+		}
+		handlers[i] = handler
+	}
+	for i, route := range swag.Routers {
+		routes.Method(strings.ToUpper(route.Method), route.Path, handlers[i])
+	}
+	Mount(routes, swag)
+
+	return nil
+}
+
+// Mount registers the Handler of the swagger on its DocsURLs and AssetsPrefix. Within a sub router the urls
+// are relative to its mount point, e.g. /api/docs for the DocsURL /docs on a router mounted on /api.
+func Mount(routes chi.Router, swag *swagger.Swagger) {
+	docs := routePathHandler(swag.Handler())
+	for _, url := range swag.DocsURLs() {
+		routes.Method(http.MethodGet, url, docs)
+	}
+	if prefix, ok := swag.AssetsPrefix(); ok {
+		routes.Method(http.MethodGet, prefix+"/*", docs)
+	}
+}
+
+// routePathHandler serves the path left by the mount points of chi
+func routePathHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		routeContext := chi.RouteContext(request.Context())
+		if routeContext == nil || routeContext.RoutePath == "" {
+			next.ServeHTTP(writer, request)
+
+			return
+		}
+		request = request.Clone(request.Context())
+		request.URL.Path = routeContext.RoutePath
+		request.URL.RawPath = ""
+		next.ServeHTTP(writer, request)
+	})
+}
@@ -0,0 +1,75 @@
+//nolint:exhaustruct, nolintlint
+package chiadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/guiyomh/swagger/pkg/swagger/swaggertest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Should register the routers and the documentation", func(t *testing.T) {
+		mux := chi.NewRouter()
+		swag, err := New(mux, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users/{id:[0-9]+}", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("user " + chi.URLParam(r, "id")))
+			}),
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+		assert.Equal(t, "user 42", rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"/users/{id}"`)
+		assert.Equal(t, "^(?:[0-9]+)$", swag.OpenAPI.Paths["/users/{id}"].Get.Parameters[0].Value.Schema.Value.Pattern)
+		swaggertest.AssertDocs(t, mux, swag, "")
+	})
+
+	t.Run("Should serve the documentation within a sub router", func(t *testing.T) {
+		mux := chi.NewRouter()
+		var swag *swagger.Swagger
+		var err error
+		mux.Route("/api", func(api chi.Router) {
+			swag, err = New(api, "foo", "bar", "1.0.0", []*router.Router{
+				router.New("/ping", http.MethodGet, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte("pong"))
+				})),
+			})
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+		assert.Equal(t, "pong", rec.Body.String())
+		swaggertest.AssertDocs(t, mux, swag, "/api")
+	})
+
+	t.Run("Should fail on an unsupported handler without registering any router", func(t *testing.T) {
+		mux := chi.NewRouter()
+		_, err := New(mux, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/health", http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+			router.New("/ping", http.MethodGet, func() {}),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, router.ErrUnsupportedHandler))
+		assert.Contains(t, err.Error(), "GET /ping")
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
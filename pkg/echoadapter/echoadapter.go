@@ -0,0 +1,107 @@
+// Package echoadapter registers the routers and the documentation endpoints on an echo instance or group
+package echoadapter
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// Routes is implemented by *echo.Echo and *echo.Group
+type Routes interface {
+	Add(method, path string, handler echo.HandlerFunc, middleware ...echo.MiddlewareFunc) *echo.Route
+}
+
+// New builds the openapi document of the routers then registers the routers and the documentation on routes
+func New(
+	routes Routes,
+	title, description, version string,
+	routers []*router.Router,
+	options ...swagger.Option,
+) (*swagger.Swagger, error) {
+	swag, err := swagger.New(title, description, version, routers, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := Register(routes, swag); err != nil {
+		return nil, err
+	}
+
+	return swag, nil
+}
+
+// Register adds the handler of each router then mounts the documentation endpoints.
+// The paths use the echo syntax, e.g. /users/:id.
+func Register(routes Routes, swag *swagger.Swagger) error {
+	// the handlers are resolved first so an unsupported one doesn't leave the routes half registered
+	handlers := make([]echo.HandlerFunc, len(swag.Routers))
+	for i, route := range swag.Routers {
+		handler, err := handlerFunc(route.Handler)
+		if err != nil {
+			return errors.Wrapf(err, "%s %s: got %T", strings.ToUpper(route.Method), route.Path, route.Handler)
+		}
+		handlers[i] = handler
+	}
+	for i, route := range swag.Routers {
+		routes.Add(strings.ToUpper(route.Method), route.Path, handlers[i])
+	}
+	Mount(routes, swag)
+
+	return nil
+}
+
+// Mount registers the Handler of the swagger on its DocsURLs and AssetsPrefix. Within a group the urls
+// are relative to the group, e.g. /api/docs for the DocsURL /docs on the /api group.
+func Mount(routes Routes, swag *swagger.Swagger) {
+	docs := swag.Handler()
+	for _, url := range swag.DocsURLs() {
+		routes.Add(http.MethodGet, url, docsHandler(docs, func(echo.Context) string { return url }))
+	}
+	if prefix, ok := swag.AssetsPrefix(); ok {
+		routes.Add(http.MethodGet, prefix+"/*", docsHandler(docs, func(c echo.Context) string {
+			return prefix + "/" + c.Param("*")
+		}))
+	}
+}
+
+// docsHandler serves the swagger url matched by the route whatever the group prefix is
+func docsHandler(docs http.Handler, url func(echo.Context) string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := c.Request().Clone(c.Request().Context())
+		request.URL.Path = url(c)
+		request.URL.RawPath = ""
+		docs.ServeHTTP(c.Response(), request)
+
+		return nil
+	}
+}
+
+// handlerFunc accepts the echo handlers and the net/http ones
+func handlerFunc(handler router.Handler) (echo.HandlerFunc, error) {
+	switch typedHandler := handler.(type) {
+	case echo.HandlerFunc:
+		return typedHandler, nil
+	case func(echo.Context) error:
+		return typedHandler, nil
+	}
+	if httpHandler, ok := router.HTTPHandler(handler); ok {
+		return func(c echo.Context) error {
+			// the net/http handlers read the path parameters with PathValue
+			values := c.ParamValues()
+			for i, name := range c.ParamNames() {
+				if i < len(values) {
+					c.Request().SetPathValue(name, values[i])
+				}
+			}
+			httpHandler.ServeHTTP(c.Response(), c.Request())
+
+			return nil
+		}, nil
+	}
+
+	return nil, errors.Wrap(router.ErrUnsupportedHandler, "echo expects an echo.HandlerFunc or a net/http handler")
+}
@@ -0,0 +1,89 @@
+//nolint:exhaustruct, nolintlint
+package echoadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger/swaggertest"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Should register the routers and the documentation", func(t *testing.T) {
+		e := echo.New()
+		swag, err := New(e, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users/:id", http.MethodGet, func(c echo.Context) error {
+				return c.String(http.StatusOK, "user "+c.Param("id"))
+			}),
+			router.New("/health", http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+		assert.Equal(t, "user 42", rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"/users/{id}"`)
+		swaggertest.AssertDocs(t, e, swag, "")
+	})
+
+	t.Run("Should serve the documentation within a group", func(t *testing.T) {
+		e := echo.New()
+		swag, err := New(e.Group("/api"), "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/ping", http.MethodGet, func(c echo.Context) error { return c.String(http.StatusOK, "pong") }),
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+		assert.Equal(t, "pong", rec.Body.String())
+		swaggertest.AssertDocs(t, e, swag, "/api")
+	})
+
+	t.Run("Should give the path parameters to the net/http handlers", func(t *testing.T) {
+		pathValueHandler := func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("user " + r.PathValue("id")))
+		}
+		e := echo.New()
+		_, err := New(e, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users/:id", http.MethodGet, pathValueHandler),
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+		assert.Equal(t, "user 42", rec.Body.String())
+	})
+
+	t.Run("Should fail on an unsupported handler without registering any router", func(t *testing.T) {
+		e := echo.New()
+		_, err := New(e, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/health", http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+			router.New("/ping", http.MethodGet, func() {}),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, router.ErrUnsupportedHandler))
+		assert.Contains(t, err.Error(), "GET /ping")
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,109 @@
+// Package fiberadapter registers the routers and the documentation endpoints on a fiber app or group
+package fiberadapter
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/pkg/errors"
+)
+
+// New builds the openapi document of the routers then registers the routers and the documentation on routes
+func New(
+	routes fiber.Router,
+	title, description, version string,
+	routers []*router.Router,
+	options ...swagger.Option,
+) (*swagger.Swagger, error) {
+	swag, err := swagger.New(title, description, version, routers, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := Register(routes, swag); err != nil {
+		return nil, err
+	}
+
+	return swag, nil
+}
+
+// Register adds the handler of each router then mounts the documentation endpoints.
+// The paths use the fiber syntax, e.g. /users/:id.
+func Register(routes fiber.Router, swag *swagger.Swagger) error {
+	// the handlers are resolved first so an unsupported one doesn't leave the routes half registered
+	handlers := make([]fiber.Handler, len(swag.Routers))
+	for i, route := range swag.Routers {
+		handler, err := handlerFunc(route.Handler)
+		if err != nil {
+			return errors.Wrapf(err, "%s %s: got %T", strings.ToUpper(route.Method), route.Path, route.Handler)
+		}
+		handlers[i] = handler
+	}
+	for i, route := range swag.Routers {
+		routes.Add(strings.ToUpper(route.Method), route.Path, handlers[i])
+	}
+	Mount(routes, swag)
+
+	return nil
+}
+
+// Mount registers the Handler of the swagger on its DocsURLs and AssetsPrefix. Within a group the urls
+// are relative to the group, e.g. /api/docs for the DocsURL /docs on the /api group.
+func Mount(routes fiber.Router, swag *swagger.Swagger) {
+	docs := adaptor.HTTPHandler(swag.Handler())
+	for _, url := range swag.DocsURLs() {
+		routes.Get(url, docsHandler(docs, func(*fiber.Ctx) string { return url }))
+	}
+	if prefix, ok := swag.AssetsPrefix(); ok {
+		routes.Get(prefix+"/*", docsHandler(docs, func(c *fiber.Ctx) string {
+			return prefix + "/" + c.Params("*")
+		}))
+	}
+}
+
+// docsHandler serves the swagger url matched by the route whatever the group prefix is
+func docsHandler(docs fiber.Handler, url func(*fiber.Ctx) string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Request().SetRequestURI(url(c))
+
+		return docs(c)
+	}
+}
+
+// handlerFunc accepts the fiber handlers and the net/http ones
+func handlerFunc(handler router.Handler) (fiber.Handler, error) {
+	if fiberHandler, ok := handler.(fiber.Handler); ok {
+		return fiberHandler, nil
+	}
+	if httpHandler, ok := router.HTTPHandler(handler); ok {
+		return pathValuesHandler(adaptor.HTTPHandler(withPathValues(httpHandler))), nil
+	}
+
+	return nil, errors.Wrap(router.ErrUnsupportedHandler, "fiber expects a fiber.Handler or a net/http handler")
+}
+
+// pathValuesKey stores the route parameters in the fasthttp context which becomes the net/http request context
+type pathValuesKey struct{}
+
+func pathValuesHandler(next fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Context().SetUserValue(pathValuesKey{}, c.AllParams())
+
+		return next(c)
+	}
+}
+
+// withPathValues lets the net/http handlers read the path parameters with PathValue
+func withPathValues(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if params, ok := request.Context().Value(pathValuesKey{}).(map[string]string); ok {
+			for name, value := range params {
+				request.SetPathValue(name, value)
+			}
+		}
+		next.ServeHTTP(writer, request)
+	})
+}
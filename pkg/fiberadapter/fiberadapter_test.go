@@ -0,0 +1,96 @@
+//nolint:exhaustruct, nolintlint
+package fiberadapter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger/swaggertest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, app *fiber.App, url string) (int, string) {
+	t.Helper()
+	response, err := app.Test(httptest.NewRequest(http.MethodGet, url, nil))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	return response.StatusCode, string(body)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Should register the routers and the documentation", func(t *testing.T) {
+		app := fiber.New()
+		swag, err := New(app, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users/:id", http.MethodGet, func(c *fiber.Ctx) error {
+				return c.SendString("user " + c.Params("id"))
+			}),
+			router.New("/health", http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		})
+		require.NoError(t, err)
+
+		_, body := get(t, app, "/users/42")
+		assert.Equal(t, "user 42", body)
+
+		status, _ := get(t, app, "/health")
+		assert.Equal(t, http.StatusNoContent, status)
+
+		status, body = get(t, app, "/openapi.json")
+		require.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, `"/users/{id}"`)
+		swaggertest.AssertDocs(t, adaptor.FiberApp(app), swag, "")
+	})
+
+	t.Run("Should serve the documentation within a group", func(t *testing.T) {
+		app := fiber.New()
+		swag, err := New(app.Group("/api"), "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/ping", http.MethodGet, func(c *fiber.Ctx) error { return c.SendString("pong") }),
+		})
+		require.NoError(t, err)
+
+		_, body := get(t, app, "/api/ping")
+		assert.Equal(t, "pong", body)
+		swaggertest.AssertDocs(t, adaptor.FiberApp(app), swag, "/api")
+	})
+
+	t.Run("Should give the path parameters to the net/http handlers", func(t *testing.T) {
+		pathValueHandler := func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("user " + r.PathValue("id")))
+		}
+		app := fiber.New()
+		_, err := New(app, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users/:id", http.MethodGet, pathValueHandler),
+		})
+		require.NoError(t, err)
+
+		_, body := get(t, app, "/users/42")
+		assert.Equal(t, "user 42", body)
+	})
+
+	t.Run("Should fail on an unsupported handler without registering any router", func(t *testing.T) {
+		app := fiber.New()
+		_, err := New(app, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/health", http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+			router.New("/ping", http.MethodGet, func() {}),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, router.ErrUnsupportedHandler))
+		assert.Contains(t, err.Error(), "GET /ping")
+
+		status, _ := get(t, app, "/health")
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,86 @@
+// Package ginadapter registers the routers and the documentation endpoints on a gin engine or group
+package ginadapter
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/pkg/errors"
+)
+
+// New builds the openapi document of the routers then registers the routers and the documentation on routes
+func New(
+	routes gin.IRoutes,
+	title, description, version string,
+	routers []*router.Router,
+	options ...swagger.Option,
+) (*swagger.Swagger, error) {
+	swag, err := swagger.New(title, description, version, routers, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := Register(routes, swag); err != nil {
+		return nil, err
+	}
+
+	return swag, nil
+}
+
+// Register adds the handler of each router then mounts the documentation endpoints
+func Register(routes gin.IRoutes, swag *swagger.Swagger) error {
+	// the handlers are resolved first so an unsupported one doesn't leave the routes half registered
+	handlers := make([]gin.HandlerFunc, len(swag.Routers))
+	for i, route := range swag.Routers {
+		handler, err := handlerFunc(route.Handler)
+		if err != nil {
+			return errors.Wrapf(err, "%s %s: got %T", strings.ToUpper(route.Method), route.Path, route.Handler)
+		}
+		handlers[i] = handler
+	}
+	for i, route := range swag.Routers {
+		routes.Handle(strings.ToUpper(route.Method), route.Path, handlers[i])
+	}
+	Mount(routes, swag)
+
+	return nil
+}
+
+// Mount registers the Handler of the swagger on its DocsURLs and AssetsPrefix. Within a group the urls
+// are relative to the group, e.g. /api/docs for the DocsURL /docs on the /api group.
+func Mount(routes gin.IRoutes, swag *swagger.Swagger) {
+	handler := swag.Handler()
+	if group, ok := routes.(interface{ BasePath() string }); ok && group.BasePath() != "/" {
+		handler = http.StripPrefix(strings.TrimSuffix(group.BasePath(), "/"), handler)
+	}
+	docs := gin.WrapH(handler)
+	for _, url := range swag.DocsURLs() {
+		routes.GET(url, docs)
+	}
+	if prefix, ok := swag.AssetsPrefix(); ok {
+		routes.GET(prefix+"/*filepath", docs)
+	}
+}
+
+// handlerFunc accepts the gin handlers and the net/http ones
+func handlerFunc(handler router.Handler) (gin.HandlerFunc, error) {
+	switch typedHandler := handler.(type) {
+	case gin.HandlerFunc:
+		return typedHandler, nil
+	case func(*gin.Context):
+		return typedHandler, nil
+	}
+	if httpHandler, ok := router.HTTPHandler(handler); ok {
+		return func(c *gin.Context) {
+			// the net/http handlers read the path parameters with PathValue
+			for _, param := range c.Params {
+				c.Request.SetPathValue(param.Key, param.Value)
+			}
+			httpHandler.ServeHTTP(c.Writer, c.Request)
+		}, nil
+	}
+
+	return nil, errors.Wrap(router.ErrUnsupportedHandler, "gin expects a gin.HandlerFunc or a net/http handler")
+}
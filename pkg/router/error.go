@@ -0,0 +1,7 @@
+package router
+
+import (
+	"github.com/pkg/errors"
+)
+
+var ErrUnsupportedHandler = errors.New("Unsupported handler type for this framework.")
@@ -0,0 +1,84 @@
+package router
+
+import (
+	"path"
+	"strings"
+)
+
+// RouterGroup builds routers sharing a path prefix, tags, security requirements, deprecation and responses
+type RouterGroup struct {
+	prefix   string
+	defaults *Router
+}
+
+// Group returns a group whose options are inherited by its routers and sub groups
+func Group(prefix string, options ...Option) *RouterGroup {
+	return &RouterGroup{prefix: prefix, defaults: New("", "", nil, options...)}
+}
+
+// Group returns a sub group, its prefix is appended to the parent one
+func (group *RouterGroup) Group(prefix string, options ...Option) *RouterGroup {
+	defaults := New("", "", nil, options...)
+	group.inherit(defaults)
+
+	return &RouterGroup{prefix: joinPath(group.prefix, prefix), defaults: defaults}
+}
+
+// New returns a router of the group. The tags are added to the group ones, the responses are merged by
+// status code and the security requirements of the router replace the group ones.
+func (group *RouterGroup) New(path, method string, handler Handler, options ...Option) *Router {
+	router := New(joinPath(group.prefix, path), method, handler, options...)
+	group.inherit(router)
+
+	return router
+}
+
+func (group *RouterGroup) inherit(router *Router) {
+	defaults := group.defaults
+	if len(defaults.Tags) > 0 {
+		tags := append([]string{}, defaults.Tags...)
+		for _, tag := range router.Tags {
+			if !contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		router.Tags = tags
+	}
+	if router.Security == nil {
+		router.Security = defaults.Security
+	}
+	router.Deprecated = router.Deprecated || defaults.Deprecated
+	if len(defaults.Responses) > 0 {
+		responses := make(map[string]*Response, len(defaults.Responses)+len(router.Responses))
+		for code, response := range defaults.Responses {
+			responses[code] = response
+		}
+		for code, response := range router.Responses {
+			responses[code] = response
+		}
+		router.Responses = responses
+	}
+}
+
+// joinPath joins the prefix and the path with a single slash, the trailing slash of the path is kept
+func joinPath(prefix, relative string) string {
+	if relative == "" {
+		return prefix
+	}
+	joined := path.Join("/", prefix, relative)
+	if strings.HasSuffix(relative, "/") && joined != "/" {
+		joined += "/"
+	}
+
+	return joined
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
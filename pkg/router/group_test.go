@@ -0,0 +1,100 @@
+//nolint:exhaustruct,nolintlint
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	unauthorized := &Response{Description: "Unauthorized"}
+	internal := &Response{Description: "Internal Server Error"}
+	api := Group("/api",
+		Tags("api"),
+		Security("bearer"),
+		Responses(map[string]*Response{"401": unauthorized, "500": internal}),
+	)
+
+	t.Run("Should inherit the group options", func(t *testing.T) {
+		rte := api.New("/ping", http.MethodGet, nil, Summary("ping"))
+
+		assert.Equal(t, "/api/ping", rte.Path)
+		assert.Equal(t, http.MethodGet, rte.Method)
+		assert.Equal(t, "ping", rte.Summary)
+		assert.Equal(t, []string{"api"}, rte.Tags)
+		require.NotNil(t, rte.Security)
+		assert.Equal(t, openapi3.SecurityRequirements{{"bearer": []string{}}}, *rte.Security)
+		assert.Equal(t, map[string]*Response{"401": unauthorized, "500": internal}, rte.Responses)
+		assert.False(t, rte.Deprecated)
+	})
+
+	t.Run("Should nest the groups", func(t *testing.T) {
+		users := api.Group("/users", Tags("users"), Deprecated())
+		rte := users.Group("/:id").New("/posts", http.MethodGet, nil)
+
+		assert.Equal(t, "/api/users/:id/posts", rte.Path)
+		assert.Equal(t, []string{"api", "users"}, rte.Tags)
+		assert.True(t, rte.Deprecated)
+		assert.Len(t, rte.Responses, 2)
+		assert.Equal(t, "/api/users", users.New("", http.MethodGet, nil).Path)
+	})
+
+	t.Run("Should let the router options override the group ones", func(t *testing.T) {
+		notFound := &Response{Description: "Not Found"}
+		publicInternal := &Response{Description: "Oops"}
+		rte := api.New("/login", http.MethodPost, nil,
+			NoSecurity(),
+			Tags("auth", "api"),
+			Responses(map[string]*Response{"404": notFound, "500": publicInternal}),
+		)
+
+		require.NotNil(t, rte.Security)
+		assert.Empty(t, *rte.Security)
+		assert.Equal(t, []string{"api", "auth"}, rte.Tags)
+		assert.Equal(t, map[string]*Response{"401": unauthorized, "404": notFound, "500": publicInternal}, rte.Responses)
+
+		rte = api.New("/admin", http.MethodGet, nil, Security("oauth", "admin"))
+		assert.Equal(t, openapi3.SecurityRequirements{{"oauth": []string{"admin"}}}, *rte.Security)
+	})
+
+	t.Run("Should not share the group state between routers", func(t *testing.T) {
+		first := api.New("/first", http.MethodGet, nil, Tags("first"))
+		second := api.New("/second", http.MethodGet, nil)
+		first.Responses["418"] = &Response{Description: "I'm a teapot"}
+
+		assert.Equal(t, []string{"api"}, second.Tags)
+		assert.NotContains(t, second.Responses, "418")
+	})
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		prefix string
+		path   string
+		want   string
+	}{
+		{prefix: "/api", path: "/users", want: "/api/users"},
+		{prefix: "/api", path: "users", want: "/api/users"},
+		{prefix: "/api/", path: "/users", want: "/api/users"},
+		{prefix: "/api", path: "/users/", want: "/api/users/"},
+		{prefix: "/api", path: "/", want: "/api/"},
+		{prefix: "", path: "users", want: "/users"},
+		{prefix: "/api", path: "", want: "/api"},
+		{prefix: "/files", path: "/{path...}", want: "/files/{path...}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, joinPath(tt.prefix, tt.path))
+		})
+	}
+
+	t.Run("Should add the slashes between nested groups", func(t *testing.T) {
+		rte := Group("/api").Group("v1").New("users", http.MethodGet, nil)
+
+		assert.Equal(t, "/api/v1/users", rte.Path)
+	})
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"net/http"
+)
+
+// HTTPHandler returns the handler as an http.Handler when it's a net/http handler
+func HTTPHandler(handler Handler) (http.Handler, bool) {
+	switch typedHandler := handler.(type) {
+	case http.Handler:
+		return typedHandler, true
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(typedHandler), true
+	default:
+		return nil, false
+	}
+}
@@ -1,5 +1,9 @@
 package router
 
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
 type Option func(router *Router)
 
 func Tags(tags ...string) Option {
@@ -48,3 +52,23 @@ func Model(model any) Option {
 		router.Model = model
 	}
 }
+
+// Security adds a security requirement to the route, each call is an alternative to the previous ones
+func Security(name string, scopes ...string) Option {
+	return func(router *Router) {
+		if scopes == nil {
+			scopes = []string{}
+		}
+		if router.Security == nil {
+			router.Security = openapi3.NewSecurityRequirements()
+		}
+		router.Security.With(openapi3.SecurityRequirement{name: scopes})
+	}
+}
+
+// NoSecurity opts the route out of the default security requirements, e.g. for public endpoints
+func NoSecurity() Option {
+	return func(router *Router) {
+		router.Security = openapi3.NewSecurityRequirements()
+	}
+}
@@ -74,3 +74,24 @@ func TestModel(t *testing.T) {
 
 	require.IsType(t, new(FakeModel), rte.Model)
 }
+
+func TestSecurity(t *testing.T) {
+	rte := &Router{}
+
+	Security("bearer")(rte)
+	Security("oauth", "read", "write")(rte)
+
+	require.NotNil(t, rte.Security)
+	require.Len(t, *rte.Security, 2)
+	require.Equal(t, []string{}, (*rte.Security)[0]["bearer"])
+	require.Equal(t, []string{"read", "write"}, (*rte.Security)[1]["oauth"])
+}
+
+func TestNoSecurity(t *testing.T) {
+	rte := &Router{}
+
+	NoSecurity()(rte)
+
+	require.NotNil(t, rte.Security)
+	require.Len(t, *rte.Security, 0)
+}
@@ -1,10 +1,18 @@
 // Package router contains the repreentation of a request routing and its response
 package router
 
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
 type Handler interface{}
 
 const (
 	MIMEApplicationJSON = "application/json"
+	MIMEApplicationForm = "application/x-www-form-urlencoded"
+	MIMEMultipartForm   = "multipart/form-data"
+	MIMEOctetStream     = "application/octet-stream"
+	MIMETextPlain       = "text/plain"
 )
 
 type Router struct {
@@ -20,6 +28,8 @@ type Router struct {
 	Model               any
 	OperationID         string
 	Responses           map[string]*Response
+	// Security overrides the default security requirements when not nil, an empty list makes the route public
+	Security *openapi3.SecurityRequirements
 }
 
 func New(path, method string, handler Handler, options ...Option) *Router {
@@ -0,0 +1,228 @@
+package router
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TypedHandler receives the decoded request and returns the response to encode
+type TypedHandler[Req, Resp any] func(ctx context.Context, request Req) (Resp, error)
+
+// HTTPError is returned by a TypedHandler to answer with another status than 500
+type HTTPError struct {
+	Status  int
+	Message string
+}
+
+func (err *HTTPError) Error() string {
+	return err.Message
+}
+
+// JSON returns a router whose model is Req and whose 200 response is Resp, so the documentation
+// follows the handler signature. The handler decodes the json body then the uri, query, header and cookie
+// fields of Req, the uri values are read with http.Request.PathValue.
+func JSON[Req, Resp any](path, method string, handler TypedHandler[Req, Resp], options ...Option) *Router {
+	options = append([]Option{
+		Model(*new(Req)),
+		Responses(map[string]*Response{
+			"200": {Description: http.StatusText(http.StatusOK), Model: *new(Resp)},
+		}),
+	}, options...)
+	router := New(path, method, jsonHandler(handler), options...)
+	router.RequestContentType = MIMEApplicationJSON
+	router.ResponseContentType = MIMEApplicationJSON
+
+	return router
+}
+
+func jsonHandler[Req, Resp any](handler TypedHandler[Req, Resp]) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var req Req
+		if err := decodeRequest(request, &req); err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+		resp, err := handler(request.Context(), req)
+		if err != nil {
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				http.Error(writer, httpErr.Message, httpErr.Status)
+
+				return
+			}
+			http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+		writer.Header().Set("Content-Type", MIMEApplicationJSON)
+		writer.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(writer).Encode(resp)
+	}
+}
+
+// decodeRequest fills the json fields of model with the body then the parameter fields with the request,
+// so the body cannot set a parameter the request left out
+func decodeRequest(request *http.Request, model any) error {
+	value := reflect.ValueOf(model).Elem()
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return decodeBody(request, value.Addr().Interface())
+	}
+	body := reflect.New(value.Type())
+	if err := decodeBody(request, body.Interface()); err != nil {
+		return err
+	}
+	copyJSONFields(value, body.Elem())
+
+	return decodeParameters(request, value)
+}
+
+func decodeBody(request *http.Request, model any) error {
+	if request.Body == nil {
+		return nil
+	}
+	if err := json.NewDecoder(request.Body).Decode(model); err != nil && !errors.Is(err, io.EOF) {
+		return errors.Wrap(err, "invalid json body")
+	}
+
+	return nil
+}
+
+// copyJSONFields copies the fields tagged json from body to value, embedded structs are walked
+func copyJSONFields(value, body reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, tagged := field.Tag.Lookup("json")
+		switch {
+		case tag == "-":
+		case tagged:
+			value.Field(i).Set(body.Field(i))
+		case field.Anonymous && field.Type.Kind() == reflect.Struct:
+			copyJSONFields(value.Field(i), body.Field(i))
+		}
+	}
+}
+
+func decodeParameters(request *http.Request, value reflect.Value) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		values, name := parameterValues(request, field.Tag)
+		if len(values) == 0 {
+			continue
+		}
+		if err := setValue(value.Field(i), values); err != nil {
+			return errors.Wrapf(err, "invalid parameter %s", name)
+		}
+	}
+
+	return nil
+}
+
+// parameterValues returns the values of the parameter described by the tag
+func parameterValues(request *http.Request, tag reflect.StructTag) ([]string, string) {
+	if name := tagName(tag, "uri"); name != "" {
+		if value := request.PathValue(name); value != "" {
+			return []string{value}, name
+		}
+	}
+	if name := tagName(tag, "query"); name != "" {
+		return request.URL.Query()[name], name
+	}
+	if name := tagName(tag, "header"); name != "" {
+		return request.Header.Values(name), name
+	}
+	if name := tagName(tag, "cookie"); name != "" {
+		if cookie, err := request.Cookie(name); err == nil {
+			return []string{cookie.Value}, name
+		}
+	}
+
+	return nil, ""
+}
+
+func tagName(tag reflect.StructTag, key string) string {
+	name, _, _ := strings.Cut(tag.Get(key), ",")
+
+	return name
+}
+
+func setValue(value reflect.Value, values []string) error {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+
+		return setValue(value.Elem(), values)
+	}
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(values[0]))
+	}
+	if value.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(value.Type(), len(values), len(values))
+		for i, item := range values {
+			if err := setValue(slice.Index(i), []string{item}); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+
+		return nil
+	}
+
+	return setScalar(value, values[0])
+}
+
+func setScalar(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return errors.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
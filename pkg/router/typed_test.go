@@ -0,0 +1,124 @@
+//nolint:exhaustruct,nolintlint
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type updateUserRequest struct {
+	ID       int      `uri:"id"`
+	Notify   bool     `query:"notify"`
+	Fields   []string `query:"fields"`
+	Token    string   `header:"X-Token"`
+	TenantID string   `header:"X-Tenant-ID"`
+	Session  *string  `cookie:"session"`
+	Name     string   `json:"name"`
+}
+
+type userResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSON(t *testing.T) {
+	var received updateUserRequest
+	rte := JSON("/users/{id}", http.MethodPut,
+		func(_ context.Context, request updateUserRequest) (userResponse, error) {
+			received = request
+			if request.ID == 0 {
+				return userResponse{}, &HTTPError{Status: http.StatusNotFound, Message: "user not found"}
+			}
+
+			return userResponse{ID: request.ID, Name: request.Name}, nil
+		},
+		Tags("users"),
+	)
+
+	t.Run("Should document the types of the handler", func(t *testing.T) {
+		assert.Equal(t, updateUserRequest{}, rte.Model)
+		require.Contains(t, rte.Responses, "200")
+		assert.Equal(t, userResponse{}, rte.Responses["200"].Model)
+		assert.Equal(t, MIMEApplicationJSON, rte.RequestContentType)
+		assert.Equal(t, MIMEApplicationJSON, rte.ResponseContentType)
+		assert.Equal(t, []string{"users"}, rte.Tags)
+	})
+
+	t.Run("Should decode the request and encode the response", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, "/users/42?notify=true&fields=a&fields=b", strings.NewReader(`{"name":"Ada"}`))
+		request.SetPathValue("id", "42")
+		request.Header.Set("X-Token", "secret")
+		request.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+		rec := httptest.NewRecorder()
+		rte.Handler.(http.Handler).ServeHTTP(rec, request)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMEApplicationJSON, rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"id":42,"name":"Ada"}`, rec.Body.String())
+		assert.True(t, received.Notify)
+		assert.Equal(t, []string{"a", "b"}, received.Fields)
+		assert.Equal(t, "secret", received.Token)
+		require.NotNil(t, received.Session)
+		assert.Equal(t, "s1", *received.Session)
+	})
+
+	t.Run("Should answer 400 on an invalid request", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, "/users/abc", strings.NewReader(`{}`))
+		request.SetPathValue("id", "abc")
+		rec := httptest.NewRecorder()
+		rte.Handler.(http.Handler).ServeHTTP(rec, request)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid parameter id")
+
+		rec = httptest.NewRecorder()
+		rte.Handler.(http.Handler).ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Should not set the parameters from the body", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, "/users/7",
+			strings.NewReader(`{"name":"Ada","ID":1,"TenantID":"other-tenant","Token":"forged","Notify":true}`))
+		request.SetPathValue("id", "7")
+		rec := httptest.NewRecorder()
+		rte.Handler.(http.Handler).ServeHTTP(rec, request)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 7, received.ID)
+		assert.Equal(t, "Ada", received.Name)
+		assert.Empty(t, received.TenantID)
+		assert.Empty(t, received.Token)
+		assert.False(t, received.Notify)
+	})
+
+	t.Run("Should answer with the status of an HTTPError", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rte.Handler.(http.Handler).ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/users/0", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "user not found")
+	})
+}
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func TestJSON_listBody(t *testing.T) {
+	rte := JSON("/items", http.MethodPost, func(_ context.Context, request []item) (int, error) {
+		return len(request), nil
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`[{"name":"a"},{"name":"b"}]`))
+	rec := httptest.NewRecorder()
+	rte.Handler.(http.Handler).ServeHTTP(rec, request)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `2`, rec.Body.String())
+}
@@ -0,0 +1,64 @@
+// Package servemuxadapter registers the routers and the documentation endpoints on a net/http ServeMux
+// using the method and wildcard patterns of go 1.22
+package servemuxadapter
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/pkg/errors"
+)
+
+// New builds the openapi document of the routers then registers the routers and the documentation on mux
+func New(
+	mux *http.ServeMux,
+	title, description, version string,
+	routers []*router.Router,
+	options ...swagger.Option,
+) (*swagger.Swagger, error) {
+	swag, err := swagger.New(title, description, version, routers, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := Register(mux, swag); err != nil {
+		return nil, err
+	}
+
+	return swag, nil
+}
+
+// Register adds the handler of each router then mounts the documentation endpoints.
+// The paths use the ServeMux syntax, e.g. /users/{id}, /files/{path...} or /users/{$}.
+func Register(mux *http.ServeMux, swag *swagger.Swagger) error {
+	// the handlers are resolved first so an unsupported one doesn't leave the routes half registered
+	handlers := make([]http.Handler, len(swag.Routers))
+	for i, route := range swag.Routers {
+		handler, ok := router.HTTPHandler(route.Handler)
+		if !ok {
+			return errors.Wrapf(
+				errors.Wrap(router.ErrUnsupportedHandler, "http.ServeMux expects a net/http handler"),
+				"%s %s: got %T", strings.ToUpper(route.Method), route.Path, route.Handler,
+			)
+		}
+		handlers[i] = handler
+	}
+	for i, route := range swag.Routers {
+		mux.Handle(strings.ToUpper(route.Method)+" "+route.Path, handlers[i])
+	}
+	Mount(mux, swag)
+
+	return nil
+}
+
+// Mount registers the Handler of the swagger on its DocsURLs and AssetsPrefix
+func Mount(mux *http.ServeMux, swag *swagger.Swagger) {
+	docs := swag.Handler()
+	for _, url := range swag.DocsURLs() {
+		mux.Handle(http.MethodGet+" "+url, docs)
+	}
+	if prefix, ok := swag.AssetsPrefix(); ok {
+		mux.Handle(http.MethodGet+" "+prefix+"/", docs)
+	}
+}
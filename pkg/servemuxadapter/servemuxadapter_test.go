@@ -0,0 +1,57 @@
+//nolint:exhaustruct, nolintlint
+package servemuxadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/guiyomh/swagger/pkg/swagger/swaggertest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Should register the routers and the documentation", func(t *testing.T) {
+		mux := http.NewServeMux()
+		swag, err := New(mux, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/files/{path...}", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("file " + r.PathValue("path")))
+			}),
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/a/b.txt", nil))
+		assert.Equal(t, "file a/b.txt", rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/files/a/b.txt", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"/files/{path}"`)
+		swaggertest.AssertDocs(t, mux, swag, "")
+	})
+
+	t.Run("Should fail on an unsupported handler without registering any router", func(t *testing.T) {
+		mux := http.NewServeMux()
+		_, err := New(mux, "foo", "bar", "1.0.0", []*router.Router{
+			router.New("/health", http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+			router.New("/ping", http.MethodGet, func() {}),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, router.ErrUnsupportedHandler))
+		assert.Contains(t, err.Error(), "GET /ping")
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,64 @@
+package swagger
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// pinned versions of the ui bundles, keep them in sync with the justfile assets recipe
+const (
+	swaggerUIVersion = "5.18.2"
+	redocVersion     = "2.1.5"
+)
+
+// AssetsSource defines where the ui pages load their javascript and css from
+type AssetsSource int
+
+const (
+	// EmbeddedAssets serves the bundles shipped within the binary under AssetsURL
+	EmbeddedAssets AssetsSource = iota
+	// CDNAssets loads the bundles from jsdelivr
+	CDNAssets
+)
+
+//go:embed assets
+var assetsFS embed.FS
+
+// uiAssets are the urls of the bundles used by the ui pages
+type uiAssets struct {
+	SwaggerUIBundle string
+	SwaggerUICSS    string
+	RedocBundle     string
+	// SwaggerUIOAuth2Redirect is the page completing the oauth2 flows, only served with EmbeddedAssets
+	SwaggerUIOAuth2Redirect string
+}
+
+func (swagger *Swagger) uiAssets(pageURL string) uiAssets {
+	if swagger.AssetsSource == CDNAssets {
+		return uiAssets{
+			SwaggerUIBundle: "https://cdn.jsdelivr.net/npm/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui-bundle.js",
+			SwaggerUICSS:    "https://cdn.jsdelivr.net/npm/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui.css",
+			RedocBundle:     "https://cdn.jsdelivr.net/npm/redoc@" + redocVersion + "/bundles/redoc.standalone.js",
+		}
+	}
+	prefix := strings.TrimSuffix(swagger.AssetsURL, "/")
+
+	return uiAssets{
+		SwaggerUIBundle:         relativeURL(pageURL, prefix+"/swagger-ui/swagger-ui-bundle.js"),
+		SwaggerUICSS:            relativeURL(pageURL, prefix+"/swagger-ui/swagger-ui.css"),
+		RedocBundle:             relativeURL(pageURL, prefix+"/redoc/redoc.standalone.js"),
+		SwaggerUIOAuth2Redirect: relativeURL(pageURL, prefix+"/swagger-ui/oauth2-redirect.html"),
+	}
+}
+
+func (swagger *Swagger) assetsHandler() http.Handler {
+	assets, err := fs.Sub(assetsFS, "assets")
+	if err != nil {
+		// the assets directory is embedded at compile time
+		panic(err)
+	}
+
+	return http.StripPrefix(strings.TrimSuffix(swagger.AssetsURL, "/"), http.FileServer(http.FS(assets)))
+}
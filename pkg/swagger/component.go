@@ -0,0 +1,187 @@
+package swagger
+
+import (
+	"mime/multipart"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fatih/structtag"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	componentSchemasPath = "#/components/schemas/"
+	// decodingSchemaSuffix names the components of the values decoded by the api when their required
+	// fields differ from the encoded ones, e.g. UserInput
+	decodingSchemaSuffix = "Input"
+)
+
+var (
+	invalidSchemaNameRe = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+	timeType            = reflect.TypeOf(time.Time{})
+	fileHeaderType      = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// SchemaNamer returns the name of the component schema describing a type
+type SchemaNamer func(reflect.Type) string
+
+// TypeSchemaName names a component schema after its go type, it's the default naming strategy
+func TypeSchemaName(modelType reflect.Type) string {
+	return sanitizeSchemaName(modelType.Name())
+}
+
+// PackageSchemaName prefixes the go type name with its package name, e.g. billing.User becomes BillingUser
+func PackageSchemaName(modelType reflect.Type) string {
+	pkg := modelType.PkgPath()
+	if index := strings.LastIndex(pkg, "/"); index >= 0 {
+		pkg = pkg[index+1:]
+	}
+	runes := []rune(sanitizeSchemaName(pkg))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+
+	return string(runes) + TypeSchemaName(modelType)
+}
+
+func sanitizeSchemaName(name string) string {
+	return strings.Trim(invalidSchemaNameRe.ReplaceAllString(name, "_"), "_")
+}
+
+// isComponent reports whether the type is described once in the components and referenced elsewhere
+func isComponent(modelType reflect.Type) bool {
+	return modelType.Kind() == reflect.Struct &&
+		modelType.Name() != "" &&
+		modelType != timeType &&
+		modelType != fileHeaderType
+}
+
+// schemaRefFromType returns a reference to the component schema of named structs
+// and an inline schema for any other type
+func (swagger *Swagger) schemaRefFromType(model any) *openapi3.SchemaRef {
+	modelType := reflect.TypeOf(model)
+	if modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	// the custom schemas and the marshalers are inlined, they usually describe a scalar
+	if modelType == nil || !isComponent(modelType) || swagger.inlineSchema(model) != nil {
+		return openapi3.NewSchemaRef("", swagger.schemaFromType(model))
+	}
+	name, ok := swagger.componentTypes(modelType)[modelType]
+	if !ok {
+		name = swagger.registerSchema(modelType, model)
+	}
+
+	return openapi3.NewSchemaRef(componentSchemasPath+name, swagger.schemas[name].Value)
+}
+
+// schemaRefFromModel is the schemaRefFromType counterpart for the models of routers and responses
+func (swagger *Swagger) schemaRefFromModel(model any) *openapi3.SchemaRef {
+	if model == nil {
+		return openapi3.NewSchemaRef("", openapi3.NewObjectSchema())
+	}
+
+	return swagger.schemaRefFromType(model)
+}
+
+func (swagger *Swagger) registerSchema(modelType reflect.Type, model any) string {
+	if swagger.schemas == nil {
+		swagger.schemas = openapi3.Schemas{}
+		swagger.schemaTypes = map[reflect.Type]string{}
+		swagger.decodingSchemaTypes = map[reflect.Type]string{}
+		swagger.decodingVariants = map[reflect.Type]bool{}
+	}
+	suffix := ""
+	if swagger.decoding && swagger.hasDecodingVariant(modelType) {
+		suffix = decodingSchemaSuffix
+	}
+	name := swagger.schemaName(modelType, suffix)
+	// the name is reserved before walking the fields so self references resolve to it
+	schema := openapi3.NewSchema()
+	swagger.componentTypes(modelType)[modelType] = name
+	swagger.schemas[name] = openapi3.NewSchemaRef("", schema)
+	*schema = *swagger.schemaFromModel(model)
+
+	return name
+}
+
+// componentTypes returns the names of the component schemas describing the types in the current direction,
+// the types whose required fields differ when decoded have their own components
+func (swagger *Swagger) componentTypes(modelType reflect.Type) map[reflect.Type]string {
+	if swagger.decoding && swagger.hasDecodingVariant(modelType) {
+		return swagger.decodingSchemaTypes
+	}
+
+	return swagger.schemaTypes
+}
+
+// hasDecodingVariant reports whether a field of the type, or of the types it holds, is required when
+// encoded but not when decoded, i.e. a json field without omitempty which is not validated as required
+func (swagger *Swagger) hasDecodingVariant(modelType reflect.Type) bool {
+	if variant, ok := swagger.decodingVariants[modelType]; ok {
+		return variant
+	}
+	variant := swagger.walkDecodingVariant(modelType, map[reflect.Type]bool{})
+	if swagger.decodingVariants == nil {
+		swagger.decodingVariants = map[reflect.Type]bool{}
+	}
+	swagger.decodingVariants[modelType] = variant
+
+	return variant
+}
+
+func (swagger *Swagger) walkDecodingVariant(modelType reflect.Type, visiting map[reflect.Type]bool) bool {
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice ||
+		modelType.Kind() == reflect.Array || modelType.Kind() == reflect.Map {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct || visiting[modelType] ||
+		swagger.inlineSchema(reflect.New(modelType).Elem().Interface()) != nil {
+		return false
+	}
+	visiting[modelType] = true
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		tags, err := structtag.Parse(string(field.Tag))
+		if err != nil {
+			continue
+		}
+		if _, err := tags.Get(EMBED); err == nil && swagger.walkDecodingVariant(field.Type, visiting) {
+			return true
+		}
+		tag, err := tags.Get(JSON)
+		if err != nil || (tag.Name == "-" && len(tag.Options) == 0) {
+			continue
+		}
+		if (alwaysEncoded(tag) && !hasRequiredRule(tags)) || swagger.walkDecodingVariant(field.Type, visiting) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaName applies the naming strategy and falls back on the package name then a counter on collisions,
+// the suffix tells apart the components of the decoded values
+func (swagger *Swagger) schemaName(modelType reflect.Type, suffix string) string {
+	namer := swagger.SchemaNamer
+	if namer == nil {
+		namer = TypeSchemaName
+	}
+	candidates := []string{namer(modelType) + suffix, PackageSchemaName(modelType) + suffix}
+	for _, name := range candidates {
+		if _, taken := swagger.schemas[name]; !taken && name != suffix {
+			return name
+		}
+	}
+	for i := 2; ; i++ {
+		name := candidates[1] + strconv.Itoa(i)
+		if _, taken := swagger.schemas[name]; !taken {
+			return name
+		}
+	}
+}
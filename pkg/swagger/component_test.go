@@ -0,0 +1,232 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type User struct {
+	Name    string  `json:"name"`
+	Address Address `json:"address" description:"home address"`
+	Office  Address `json:"office"`
+}
+
+func TestSwagger_components(t *testing.T) {
+	t.Run("Should reference the same component from every response", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users", http.MethodGet, nil, router.Responses(router.ResponseMap{
+				"200": {Description: "list", Model: []User{}},
+			})),
+			router.New("/users/me", http.MethodGet, nil, router.Responses(router.ResponseMap{
+				"200": {Description: "one", Model: new(User)},
+			})),
+		})
+		require.NoError(t, err)
+		require.NoError(t, swag.OpenAPI.Validate(context.Background()))
+
+		schemas := swag.OpenAPI.Components.Schemas
+		require.Len(t, schemas, 2)
+		require.Contains(t, schemas, "User")
+		require.Contains(t, schemas, "Address")
+
+		list := swag.OpenAPI.Paths["/users"].Get.Responses["200"].Value.Content.Get("application/json").Schema
+		assert.Equal(t, openapi3.TypeArray, list.Value.Type)
+		assert.Equal(t, "#/components/schemas/User", list.Value.Items.Ref)
+		one := swag.OpenAPI.Paths["/users/me"].Get.Responses["200"].Value.Content.Get("application/json").Schema
+		assert.Equal(t, "#/components/schemas/User", one.Ref)
+	})
+
+	t.Run("Should reference nested structs and keep their annotations", func(t *testing.T) {
+		swag := &Swagger{}
+
+		ref := swag.schemaRefFromModel(User{})
+		user := swag.schemas["User"].Value
+
+		assert.Equal(t, "#/components/schemas/User", ref.Ref)
+		assert.Equal(t, "#/components/schemas/Address", user.Properties["office"].Ref)
+		address := user.Properties["address"]
+		assert.Empty(t, address.Ref)
+		assert.Equal(t, "home address", address.Value.Description)
+		require.Len(t, address.Value.AllOf, 1)
+		assert.Equal(t, "#/components/schemas/Address", address.Value.AllOf[0].Ref)
+	})
+
+	t.Run("Should not collide on types sharing the same name", func(t *testing.T) {
+		swag := &Swagger{}
+		otherUser := func() any {
+			type User struct {
+				ID int `json:"id"`
+			}
+
+			return User{}
+		}()
+
+		first := swag.schemaRefFromModel(User{})
+		second := swag.schemaRefFromModel(otherUser)
+
+		assert.Equal(t, "#/components/schemas/User", first.Ref)
+		assert.Equal(t, "#/components/schemas/SwaggerUser", second.Ref)
+		assert.Contains(t, swag.schemas["SwaggerUser"].Value.Properties, "id")
+	})
+
+	t.Run("Should keep the names of colliding types between builds", func(t *testing.T) {
+		otherUser := func() any {
+			type User struct {
+				ID int `json:"id"`
+			}
+
+			return User{}
+		}()
+		webhook := func(model any) *router.Router {
+			return router.New("", http.MethodPost, nil, router.Responses(router.ResponseMap{
+				"200": {Description: "received", Model: model},
+			}))
+		}
+
+		for i := 0; i < 20; i++ {
+			swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+				router.New("/users", http.MethodGet, nil, router.Responses(router.ResponseMap{
+					"201": {Description: "other", Model: otherUser},
+					"200": {Description: "user", Model: User{}},
+				})),
+			})
+			require.NoError(t, err)
+			responses := swag.OpenAPI.Paths["/users"].Get.Responses
+			assert.Equal(t, "#/components/schemas/User", responses.Get(http.StatusOK).Value.Content.Get("application/json").Schema.Ref)
+			assert.Equal(t, "#/components/schemas/SwaggerUser", responses.Get(http.StatusCreated).Value.Content.Get("application/json").Schema.Ref)
+
+			swag, err = New("foo", "bar", "1.0.0", nil, WithWebhook("b", webhook(otherUser)), WithWebhook("a", webhook(User{})))
+			require.NoError(t, err)
+			webhooks := swag.OpenAPI.Extensions[webhooksExtension].(openapi3.Paths)
+			// the api decodes the responses of its webhooks
+			received := webhooks["a"].Post.Responses.Get(http.StatusOK).Value.Content.Get("application/json")
+			assert.Equal(t, "#/components/schemas/UserInput", received.Schema.Ref)
+		}
+	})
+
+	t.Run("Should use the naming strategy", func(t *testing.T) {
+		swag := &Swagger{SchemaNamer: func(modelType reflect.Type) string {
+			return "Api" + modelType.Name()
+		}}
+
+		ref := swag.schemaRefFromModel(User{})
+
+		assert.Equal(t, "#/components/schemas/ApiUser", ref.Ref)
+		assert.Contains(t, swag.schemas, "ApiAddress")
+	})
+
+	t.Run("Should use the naming strategy given to New", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/users", http.MethodGet, nil, router.Responses(router.ResponseMap{
+				"200": {Description: "user", Model: User{}},
+			})),
+		}, WithSchemaNamer(PackageSchemaName))
+		require.NoError(t, err)
+
+		schema := swag.OpenAPI.Paths["/users"].Get.Responses.Get(http.StatusOK).Value.Content.Get("application/json").Schema
+		assert.Equal(t, "#/components/schemas/SwaggerUser", schema.Ref)
+		assert.Contains(t, swag.OpenAPI.Components.Schemas, "SwaggerAddress")
+		assert.NotContains(t, swag.OpenAPI.Components.Schemas, "User")
+	})
+}
+
+func TestPackageSchemaName(t *testing.T) {
+	assert.Equal(t, "SwaggerUser", PackageSchemaName(reflect.TypeOf(User{})))
+}
+
+type TreeNode struct {
+	Name     string     `json:"name"`
+	Children []TreeNode `json:"children"`
+}
+
+type ListNode struct {
+	Value int       `json:"value"`
+	Next  *ListNode `json:"next"`
+}
+
+type Author struct {
+	Name  string `json:"name"`
+	Books []Book `json:"books"`
+}
+
+type Book struct {
+	Title  string  `json:"title"`
+	Author *Author `json:"author"`
+}
+
+type Forest []Forest
+
+func TestSwagger_recursiveTypes(t *testing.T) {
+	t.Run("Should reference a tree from its children", func(t *testing.T) {
+		swag := &Swagger{}
+
+		ref := swag.schemaRefFromModel(new(TreeNode))
+
+		assert.Equal(t, "#/components/schemas/TreeNode", ref.Ref)
+		children := swag.schemas["TreeNode"].Value.Properties["children"].Value
+		assert.Equal(t, openapi3.TypeArray, children.Type)
+		assert.Equal(t, "#/components/schemas/TreeNode", children.Items.Ref)
+	})
+
+	t.Run("Should reference a linked list from its next pointer", func(t *testing.T) {
+		swag := &Swagger{}
+
+		ref := swag.schemaRefFromModel(ListNode{})
+
+		assert.Equal(t, "#/components/schemas/ListNode", ref.Ref)
+		next := swag.schemas["ListNode"].Value.Properties["next"].Value
+		assert.True(t, next.Nullable)
+		assert.Equal(t, "#/components/schemas/ListNode", next.AllOf[0].Ref)
+	})
+
+	t.Run("Should reference mutually recursive types", func(t *testing.T) {
+		swag := &Swagger{}
+
+		swag.schemaRefFromModel(new(Author))
+
+		require.Len(t, swag.schemas, 2)
+		books := swag.schemas["Author"].Value.Properties["books"].Value
+		assert.Equal(t, "#/components/schemas/Book", books.Items.Ref)
+		author := swag.schemas["Book"].Value.Properties["author"].Value
+		assert.Equal(t, "#/components/schemas/Author", author.AllOf[0].Ref)
+	})
+
+	t.Run("Should stop on a cycle which is not a component", func(t *testing.T) {
+		swag := &Swagger{}
+
+		schema := swag.schemaFromModel(Forest{})
+
+		assert.Equal(t, openapi3.TypeArray, schema.Type)
+		assert.Empty(t, schema.Items.Value.Type)
+	})
+
+	t.Run("Should build a valid document from recursive responses", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/tree", http.MethodPost, nil,
+				router.Model(new(TreeNode)),
+				router.Responses(router.ResponseMap{
+					"200": {Description: "tree", Model: TreeNode{}},
+					"201": {Description: "list", Model: ListNode{}},
+					"202": {Description: "authors", Model: []Author{}},
+				}),
+			),
+		})
+		require.NoError(t, err)
+		require.NoError(t, swag.OpenAPI.Validate(context.Background()))
+
+		_, err = swag.MarshalJSON()
+		require.NoError(t, err)
+	})
+}
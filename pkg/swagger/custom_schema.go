@@ -0,0 +1,126 @@
+package swagger
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SchemaProvider is implemented by the types describing their own schema, e.g. a money or an uuid type
+type SchemaProvider interface {
+	OpenAPISchema() *openapi3.Schema
+}
+
+var schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+
+// RegisterType describes a type which can't implement SchemaProvider, e.g. a type of a third party package.
+// The document is rebuilt so the routers using the type get its schema.
+func (swagger *Swagger) RegisterType(modelType reflect.Type, schema func() *openapi3.Schema) error {
+	swagger.registerType(modelType, schema)
+
+	return swagger.buildOpenAPI()
+}
+
+func (swagger *Swagger) registerType(modelType reflect.Type, schema func() *openapi3.Schema) {
+	if swagger.customTypes == nil {
+		swagger.customTypes = map[reflect.Type]func() *openapi3.Schema{}
+	}
+	swagger.customTypes[modelType] = schema
+}
+
+// customSchema returns the schema of a registered type or of a SchemaProvider, nil for any other type.
+// The schema is deep copied since the tags of each field annotate it, down to its items and properties.
+func (swagger *Swagger) customSchema(model any) *openapi3.Schema {
+	modelType := reflect.TypeOf(model)
+	if modelType == nil {
+		return nil
+	}
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	var schema *openapi3.Schema
+	if custom, ok := swagger.customTypes[modelType]; ok {
+		schema = custom()
+	} else if provider, ok := asInterface(model, schemaProviderType); ok {
+		schema = provider.(SchemaProvider).OpenAPISchema() //nolint:forcetypeassert,nolintlint
+	}
+	if schema == nil {
+		return nil
+	}
+
+	return cloneSchema(schema)
+}
+
+// cloneSchema deep copies an inline schema, the referenced components are shared
+func cloneSchema(schema *openapi3.Schema) *openapi3.Schema {
+	copied := *schema
+	copied.Extensions = cloneMap(schema.Extensions)
+	copied.OneOf = cloneSchemaRefs(schema.OneOf)
+	copied.AnyOf = cloneSchemaRefs(schema.AnyOf)
+	copied.AllOf = cloneSchemaRefs(schema.AllOf)
+	copied.Not = cloneSchemaRef(schema.Not)
+	copied.Items = cloneSchemaRef(schema.Items)
+	copied.AdditionalProperties = cloneSchemaRef(schema.AdditionalProperties)
+	copied.Enum = append([]interface{}(nil), schema.Enum...)
+	copied.Required = append([]string(nil), schema.Required...)
+	copied.Min = clonePointer(schema.Min)
+	copied.Max = clonePointer(schema.Max)
+	copied.MultipleOf = clonePointer(schema.MultipleOf)
+	copied.MaxLength = clonePointer(schema.MaxLength)
+	copied.MaxItems = clonePointer(schema.MaxItems)
+	copied.MaxProps = clonePointer(schema.MaxProps)
+	copied.AdditionalPropertiesAllowed = clonePointer(schema.AdditionalPropertiesAllowed)
+	if schema.Properties != nil {
+		copied.Properties = make(openapi3.Schemas, len(schema.Properties))
+		for name, property := range schema.Properties {
+			copied.Properties[name] = cloneSchemaRef(property)
+		}
+	}
+
+	return &copied
+}
+
+func cloneSchemaRef(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil {
+		return nil
+	}
+	copied := *ref
+	if ref.Ref == "" && ref.Value != nil {
+		copied.Value = cloneSchema(ref.Value)
+	}
+
+	return &copied
+}
+
+func cloneSchemaRefs(refs openapi3.SchemaRefs) openapi3.SchemaRefs {
+	if refs == nil {
+		return nil
+	}
+	copied := make(openapi3.SchemaRefs, len(refs))
+	for i, ref := range refs {
+		copied[i] = cloneSchemaRef(ref)
+	}
+
+	return copied
+}
+
+func cloneMap(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+
+	return &copied
+}
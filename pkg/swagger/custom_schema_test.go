@@ -0,0 +1,123 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"database/sql"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UUID [16]byte
+
+func (UUID) OpenAPISchema() *openapi3.Schema {
+	return openapi3.NewUUIDSchema()
+}
+
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func (*Money) OpenAPISchema() *openapi3.Schema {
+	schema := openapi3.NewStringSchema()
+	schema.Pattern = `^\d+\.\d{2} [A-Z]{3}$`
+
+	return schema
+}
+
+type Invoice struct {
+	ID     UUID           `json:"id" description:"invoice id"`
+	Owner  *UUID          `json:"owner"`
+	Total  Money          `json:"total"`
+	Lines  []Money        `json:"lines"`
+	Note   sql.NullString `json:"note"`
+	Parent UUID           `json:"parent"`
+}
+
+func TestSwagger_customSchema(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/invoices", http.MethodGet, nil, router.Responses(router.ResponseMap{
+			"200": {Description: "OK", Model: Invoice{}},
+		})),
+	}, WithType(reflect.TypeOf(sql.NullString{}), func() *openapi3.Schema {
+		return openapi3.NewStringSchema().WithNullable()
+	}))
+	require.NoError(t, err)
+	properties := swag.OpenAPI.Components.Schemas["Invoice"].Value.Properties
+
+	t.Run("Should use the schema of a SchemaProvider", func(t *testing.T) {
+		assert.Equal(t, "string", properties["id"].Value.Type)
+		assert.Equal(t, "uuid", properties["id"].Value.Format)
+		assert.Equal(t, "uuid", properties["owner"].Value.Format)
+		assert.Equal(t, `^\d+\.\d{2} [A-Z]{3}$`, properties["total"].Value.Pattern)
+		assert.Equal(t, "string", properties["lines"].Value.Items.Value.Type)
+		assert.NotContains(t, swag.OpenAPI.Components.Schemas, "Money")
+	})
+
+	t.Run("Should copy the schema before annotating it", func(t *testing.T) {
+		assert.Equal(t, "invoice id", properties["id"].Value.Description)
+		assert.Empty(t, properties["parent"].Value.Description)
+	})
+
+	t.Run("Should use the schema of a registered type", func(t *testing.T) {
+		assert.Equal(t, "string", properties["note"].Value.Type)
+		assert.True(t, properties["note"].Value.Nullable)
+		assert.NotContains(t, swag.OpenAPI.Components.Schemas, "NullString")
+	})
+
+	t.Run("Should rebuild the document when a type is registered", func(t *testing.T) {
+		require.NoError(t, swag.RegisterType(reflect.TypeOf(sql.NullString{}), func() *openapi3.Schema {
+			return openapi3.NewStringSchema().WithMaxLength(10)
+		}))
+		note := swag.OpenAPI.Components.Schemas["Invoice"].Value.Properties["note"].Value
+		require.NotNil(t, note.MaxLength)
+		assert.Equal(t, uint64(10), *note.MaxLength)
+		assert.False(t, note.Nullable)
+	})
+}
+
+// codesSchema is shared by every Codes value, the annotations of a field must not leak into it
+var codesSchema = openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema().WithPattern(`^[A-Z]+$`))
+
+type Codes []string
+
+func (Codes) OpenAPISchema() *openapi3.Schema {
+	return codesSchema
+}
+
+func TestSwagger_customSchemaCopy(t *testing.T) {
+	type Country struct {
+		Alpha3 Codes `json:"alpha3" validate:"dive,len=3,alphanum"`
+		Others Codes `json:"others"`
+	}
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/countries", http.MethodGet, nil, router.Responses(router.ResponseMap{
+			"200": {Description: "OK", Model: Country{}},
+		})),
+	})
+	require.NoError(t, err)
+	properties := swag.OpenAPI.Components.Schemas["Country"].Value.Properties
+
+	t.Run("Should annotate the items of the field only", func(t *testing.T) {
+		alpha3 := properties["alpha3"].Value.Items.Value
+		assert.Equal(t, uint64(3), alpha3.MinLength)
+		assert.Len(t, alpha3.AllOf, 1)
+
+		others := properties["others"].Value.Items.Value
+		assert.Zero(t, others.MinLength)
+		assert.Empty(t, others.AllOf)
+	})
+
+	t.Run("Should leave the schema of the provider untouched", func(t *testing.T) {
+		items := codesSchema.Items.Value
+		assert.Zero(t, items.MinLength)
+		assert.Nil(t, items.MaxLength)
+		assert.Empty(t, items.AllOf)
+	})
+}
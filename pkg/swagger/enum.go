@@ -0,0 +1,93 @@
+package swagger
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const enumVarNamesExtension = "x-enum-varnames"
+
+// Enumer is implemented by the enum types, e.g. a type Status string with a const block of values.
+// Enum lists the values allowed in the schema.
+type Enumer interface {
+	Enum() []any
+}
+
+// EnumVarNamer names the values returned by Enum, the names are given to the generated clients
+// with the x-enum-varnames extension
+type EnumVarNamer interface {
+	EnumVarNames() []string
+}
+
+var (
+	enumerType       = reflect.TypeOf((*Enumer)(nil)).Elem()
+	enumVarNamerType = reflect.TypeOf((*EnumVarNamer)(nil)).Elem()
+)
+
+// withEnum adds the values of an Enumer to its schema, converted to the type the values are encoded to
+func withEnum(schema *openapi3.Schema, model any) *openapi3.Schema {
+	enumer, ok := asInterface(model, enumerType)
+	if !ok || len(schema.Enum) > 0 {
+		return schema
+	}
+	values := enumer.(Enumer).Enum() //nolint:forcetypeassert,nolintlint
+	enums := make([]interface{}, len(values))
+	for i, value := range values {
+		enums[i] = enumValueOf(schema, value)
+	}
+	schema.WithEnum(enums...)
+	if namer, ok := asInterface(model, enumVarNamerType); ok {
+		names := namer.(EnumVarNamer).EnumVarNames() //nolint:forcetypeassert,nolintlint
+		if len(names) == len(values) {
+			extensions := map[string]interface{}{enumVarNamesExtension: names}
+			for key, extension := range schema.Extensions {
+				extensions[key] = extension
+			}
+			schema.Extensions = extensions
+		}
+	}
+
+	return schema
+}
+
+// enumValueOf returns the text of a TextMarshaler described as a string, the predeclared type of any other value
+func enumValueOf(schema *openapi3.Schema, value any) interface{} {
+	if marshaler, ok := value.(encoding.TextMarshaler); ok && schema.Type == openapi3.TypeString {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	return underlyingValue(reflect.ValueOf(value))
+}
+
+// asInterface returns the model, or a pointer to it, as the interface it implements. A nil pointer
+// is replaced by the zero value so the methods with a value receiver can be called.
+func asInterface(model any, interfaceType reflect.Type) (any, bool) {
+	modelType := reflect.TypeOf(model)
+	if modelType == nil {
+		return nil, false
+	}
+	value := reflect.ValueOf(model)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+		if value.IsNil() {
+			value = reflect.New(modelType).Elem()
+		} else {
+			value = value.Elem()
+		}
+	}
+	if modelType.Implements(interfaceType) {
+		return value.Interface(), true
+	}
+	if reflect.PointerTo(modelType).Implements(interfaceType) {
+		pointer := reflect.New(modelType)
+		pointer.Elem().Set(value)
+
+		return pointer.Interface(), true
+	}
+
+	return nil, false
+}
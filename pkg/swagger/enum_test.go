@@ -0,0 +1,101 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusArchived Status = "archived"
+)
+
+func (Status) Enum() []any {
+	return []any{StatusActive, StatusArchived}
+}
+
+func (Status) EnumVarNames() []string {
+	return []string{"StatusActive", "StatusArchived"}
+}
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityHigh
+)
+
+func (*Priority) Enum() []any {
+	return []any{PriorityLow, PriorityHigh}
+}
+
+type Weekday int
+
+func (day Weekday) MarshalText() ([]byte, error) {
+	return []byte([]string{"sunday", "monday"}[day]), nil
+}
+
+func (Weekday) Enum() []any {
+	return []any{Weekday(0), Weekday(1)}
+}
+
+type Task struct {
+	Status   Status     `json:"status"`
+	Previous *Status    `json:"previous"`
+	Priority Priority   `json:"priority"`
+	Day      Weekday    `json:"day"`
+	History  []Status   `json:"history"`
+	Level    int        `json:"level" validate:"enum=1,2,3"`
+	Filter   Status     `json:"filter" validate:"oneof=active"`
+	Owner    string     `json:"owner"`
+	Labels   []Priority `json:"labels"`
+}
+
+func TestSwagger_enum(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/tasks", http.MethodGet, nil, router.Responses(router.ResponseMap{
+			"200": {Description: "OK", Model: Task{}},
+		})),
+	})
+	require.NoError(t, err)
+	properties := swag.OpenAPI.Components.Schemas["Task"].Value.Properties
+
+	t.Run("Should list the values of an Enumer", func(t *testing.T) {
+		assert.Equal(t, "string", properties["status"].Value.Type)
+		assert.Equal(t, []interface{}{"active", "archived"}, properties["status"].Value.Enum)
+		assert.Equal(t, []interface{}{"active", "archived"}, properties["previous"].Value.Enum)
+		assert.Equal(t, []interface{}{"active", "archived"}, properties["history"].Value.Items.Value.Enum)
+		assert.Empty(t, properties["owner"].Value.Enum)
+	})
+
+	t.Run("Should keep the type of the values", func(t *testing.T) {
+		assert.Equal(t, "integer", properties["priority"].Value.Type)
+		assert.Equal(t, []interface{}{1, 2}, properties["priority"].Value.Enum)
+		assert.Equal(t, []interface{}{1, 2}, properties["labels"].Value.Items.Value.Enum)
+		assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, properties["level"].Value.Enum)
+	})
+
+	t.Run("Should use the text of a TextMarshaler", func(t *testing.T) {
+		assert.Equal(t, "string", properties["day"].Value.Type)
+		assert.Equal(t, []interface{}{"sunday", "monday"}, properties["day"].Value.Enum)
+	})
+
+	t.Run("Should name the values", func(t *testing.T) {
+		assert.Equal(t, []string{"StatusActive", "StatusArchived"}, properties["status"].Value.Extensions["x-enum-varnames"])
+		assert.NotContains(t, properties["priority"].Value.Extensions, "x-enum-varnames")
+		body, err := swag.MarshalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"x-enum-varnames":["StatusActive","StatusArchived"]`)
+	})
+
+	t.Run("Should let a validate tag narrow the values", func(t *testing.T) {
+		assert.Equal(t, []interface{}{"active"}, properties["filter"].Value.Enum)
+	})
+}
@@ -10,4 +10,12 @@ var (
 	ErrParseLenOption  = errors.New("Cannot parse len option. the right syntaxe is validate:\"len=1\".")
 	ErrParseEnumOption = errors.New("Cannot parse enum option. the right syntaxe is validate:\"enum=red,blue,green\".")
 	ErrNoInParameter   = errors.New("No In parameters")
+
+	ErrParseValidateOption = errors.New("Cannot parse validate option.")
+	ErrParseAnnotationTag  = errors.New("Cannot parse the default or example tag to the type of the field.")
+
+	ErrUnknownSecurityScheme = errors.New("Unknown security scheme. register it with WithSecurityScheme.")
+	ErrSwagger2Conversion    = errors.New("Cannot convert the openapi document to swagger 2.0.")
+	ErrUndocumentedRoute     = errors.New("The route is not documented.")
+	ErrMissingResponseHeader = errors.New("The required response header is missing.")
 )
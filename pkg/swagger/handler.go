@@ -0,0 +1,168 @@
+package swagger
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/guiyomh/swagger/pkg/router"
+)
+
+const (
+	acceptHeader      = "Accept"
+	contentTypeHeader = "Content-Type"
+	mimeTextHTML      = "text/html; charset=utf-8"
+	mimeYAML          = "application/yaml"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var templates = template.Must(template.ParseFS(templatesFS, "templates/*.html"))
+
+// uiPage is the data given to the swagger and redoc templates
+type uiPage struct {
+	Title      string
+	OpenAPIURL string
+	Options    map[string]interface{}
+	Assets     uiAssets
+}
+
+// Handler returns an http.Handler serving the openapi specification on OpenAPIURL and OpenAPIYAMLURL,
+// the Swagger UI on DocsURL and Redoc on RedocURL. An empty url disables the matching endpoint.
+// OpenAPIURL answers in yaml when the Accept header asks for it.
+// With EmbeddedAssets, the ui bundles are served under AssetsURL.
+func (swagger *Swagger) Handler() http.Handler {
+	mux := http.NewServeMux()
+	if swagger.OpenAPIURL != "" {
+		mux.HandleFunc(swagger.OpenAPIURL, swagger.serveOpenAPI)
+	}
+	if swagger.OpenAPIYAMLURL != "" {
+		mux.HandleFunc(swagger.OpenAPIYAMLURL, swagger.serveOpenAPIYAML)
+	}
+	if swagger.DocsURL != "" {
+		mux.HandleFunc(swagger.DocsURL, swagger.serveUI(swagger.DocsURL, "swagger.html", swagger.SwaggerOptions))
+	}
+	if swagger.RedocURL != "" {
+		mux.HandleFunc(swagger.RedocURL, swagger.serveUI(swagger.RedocURL, "redoc.html", swagger.RedocOptions))
+	}
+	if prefix, ok := swagger.AssetsPrefix(); ok {
+		mux.Handle(prefix+"/", swagger.assetsHandler())
+	}
+
+	return mux
+}
+
+// DocsURLs returns the enabled urls served by Handler, the adapters register each one of them
+func (swagger *Swagger) DocsURLs() []string {
+	urls := []string{}
+	for _, url := range []string{swagger.OpenAPIURL, swagger.OpenAPIYAMLURL, swagger.DocsURL, swagger.RedocURL} {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	return urls
+}
+
+// AssetsPrefix returns the url, without trailing slash, under which Handler serves the embedded assets.
+// It reports false when the assets are loaded from the cdn or disabled.
+func (swagger *Swagger) AssetsPrefix() (string, bool) {
+	if swagger.AssetsSource != EmbeddedAssets || swagger.AssetsURL == "" {
+		return "", false
+	}
+
+	return strings.TrimSuffix(swagger.AssetsURL, "/"), true
+}
+
+func (swagger *Swagger) serveOpenAPI(writer http.ResponseWriter, request *http.Request) {
+	if acceptsYAML(request.Header.Get(acceptHeader)) {
+		swagger.serveOpenAPIYAML(writer, request)
+
+		return
+	}
+	body, err := swagger.MarshalJSON()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	writer.Header().Set(contentTypeHeader, router.MIMEApplicationJSON)
+	_, _ = writer.Write(body)
+}
+
+func (swagger *Swagger) serveOpenAPIYAML(writer http.ResponseWriter, _ *http.Request) {
+	body, err := swagger.MarshalYAML()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	writer.Header().Set(contentTypeHeader, mimeYAML)
+	_, _ = writer.Write(body)
+}
+
+// acceptsYAML reports whether the Accept header prefers yaml, json stays the default
+func acceptsYAML(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(mediaRange, ";", 2)[0])
+		switch mediaType {
+		case router.MIMEApplicationJSON, "*/*":
+			return false
+		case mimeYAML, "application/x-yaml", "text/yaml", "text/x-yaml":
+			return true
+		}
+	}
+
+	return false
+}
+
+// serveUI renders a ui page, its links are relative to pageURL so the docs keep working
+// when they are mounted under a prefix
+func (swagger *Swagger) serveUI(pageURL, name string, options map[string]interface{}) http.HandlerFunc {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+
+	return func(writer http.ResponseWriter, _ *http.Request) {
+		var buf bytes.Buffer
+		page := uiPage{
+			Title:      swagger.Title,
+			OpenAPIURL: relativeURL(pageURL, swagger.OpenAPIURL),
+			Options:    options,
+			Assets:     swagger.uiAssets(pageURL),
+		}
+		if err := templates.ExecuteTemplate(&buf, name, page); err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+		writer.Header().Set(contentTypeHeader, mimeTextHTML)
+		_, _ = writer.Write(buf.Bytes())
+	}
+}
+
+// relativeURL returns the path of target relative to the directory of page, e.g. openapi.json from /docs
+func relativeURL(page, target string) string {
+	if !strings.HasPrefix(page, "/") || !strings.HasPrefix(target, "/") {
+		return target
+	}
+	dir := strings.Split(strings.TrimPrefix(path.Dir(page), "/"), "/")
+	if dir[0] == "" {
+		dir = nil
+	}
+	parts := strings.Split(strings.TrimPrefix(target, "/"), "/")
+	common := 0
+	for common < len(dir) && common < len(parts)-1 && dir[common] == parts[common] {
+		common++
+	}
+	relative := strings.Repeat("../", len(dir)-common) + strings.Join(parts[common:], "/")
+	if relative == "" {
+		return "."
+	}
+
+	return relative
+}
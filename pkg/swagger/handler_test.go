@@ -0,0 +1,185 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger_Handler(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/hello/:name", http.MethodGet, func() {}),
+	})
+	require.NoError(t, err)
+	swag.SwaggerOptions = map[string]interface{}{"docExpansion": "none"}
+	swag.RedocOptions = map[string]interface{}{"hideDownloadButton": true}
+	handler := swag.Handler()
+
+	t.Run("Should serve the openapi specification", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"/hello/{name}"`)
+	})
+
+	t.Run("Should serve the yaml openapi specification", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "openapi: 3.0.0")
+		assert.Contains(t, rec.Body.String(), "/hello/{name}:")
+	})
+
+	t.Run("Should negotiate the openapi specification format", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+		request.Header.Set("Accept", "application/yaml;q=0.9, */*;q=0.1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, request)
+		assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+
+		request.Header.Set("Accept", "application/json, application/yaml")
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, request)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("Should serve the swagger ui", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rec.Body.String(), "SwaggerUIBundle")
+		assert.Contains(t, rec.Body.String(), `"openapi.json"`)
+		assert.Contains(t, rec.Body.String(), `{"docExpansion":"none"}`)
+	})
+
+	t.Run("Should serve redoc", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Redoc.init")
+		assert.Contains(t, rec.Body.String(), `{"hideDownloadButton":true}`)
+	})
+
+	t.Run("Should serve the embedded assets", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Contains(t, rec.Body.String(), `src="docs/assets/swagger-ui/swagger-ui-bundle.js"`)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui/swagger-ui.css", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/css")
+	})
+
+	t.Run("Should serve the redoc bundle", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+		assert.Contains(t, rec.Body.String(), `src="docs/assets/redoc/redoc.standalone.js"`)
+
+		if _, err := fs.Stat(assetsFS, "assets/redoc/redoc.standalone.js"); err != nil {
+			t.Skip("the redoc bundle is not vendored, run just assets")
+		}
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/redoc/redoc.standalone.js", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "javascript")
+	})
+
+	t.Run("Should complete the oauth2 flows with the embedded redirect page", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Contains(t, rec.Body.String(), `oauth2RedirectUrl: new URL("docs/assets/swagger-ui/oauth2-redirect.html"`)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui/oauth2-redirect.html", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Should ship the swagger ui license", func(t *testing.T) {
+		_, err := fs.Stat(assetsFS, "assets/swagger-ui/LICENSE")
+		require.NoError(t, err)
+	})
+
+	t.Run("Should load the assets from the cdn", func(t *testing.T) {
+		swag.AssetsSource = CDNAssets
+		defer func() { swag.AssetsSource = EmbeddedAssets }()
+		cdnHandler := swag.Handler()
+
+		rec := httptest.NewRecorder()
+		cdnHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+		assert.Contains(t, rec.Body.String(), "https://cdn.jsdelivr.net/npm/redoc@")
+
+		rec = httptest.NewRecorder()
+		cdnHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.NotContains(t, rec.Body.String(), "oauth2RedirectUrl")
+
+		rec = httptest.NewRecorder()
+		cdnHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui/swagger-ui.css", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Should not serve a disabled endpoint", func(t *testing.T) {
+		swag.RedocURL = ""
+		rec := httptest.NewRecorder()
+		swag.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestSwagger_DocsURLs(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", nil)
+	require.NoError(t, err)
+
+	t.Run("Should list the enabled urls", func(t *testing.T) {
+		assert.Equal(t, []string{"/openapi.json", "/openapi.yaml", "/docs", "/redoc"}, swag.DocsURLs())
+
+		swag.OpenAPIYAMLURL = ""
+		defer func() { swag.OpenAPIYAMLURL = "/openapi.yaml" }()
+		assert.Equal(t, []string{"/openapi.json", "/docs", "/redoc"}, swag.DocsURLs())
+	})
+
+	t.Run("Should give the prefix of the embedded assets", func(t *testing.T) {
+		prefix, ok := swag.AssetsPrefix()
+		assert.True(t, ok)
+		assert.Equal(t, "/docs/assets", prefix)
+
+		swag.AssetsSource = CDNAssets
+		defer func() { swag.AssetsSource = EmbeddedAssets }()
+		_, ok = swag.AssetsPrefix()
+		assert.False(t, ok)
+	})
+}
+
+func TestRelativeURL(t *testing.T) {
+	tests := []struct {
+		page   string
+		target string
+		want   string
+	}{
+		{page: "/docs", target: "/openapi.json", want: "openapi.json"},
+		{page: "/docs", target: "/docs/assets/swagger-ui/swagger-ui.css", want: "docs/assets/swagger-ui/swagger-ui.css"},
+		{page: "/api/docs", target: "/api/openapi.json", want: "openapi.json"},
+		{page: "/ui/docs", target: "/spec/openapi.json", want: "../spec/openapi.json"},
+		{page: "/docs/", target: "/openapi.json", want: "../openapi.json"},
+		{page: "/docs", target: "https://cdn.example.com/ui.js", want: "https://cdn.example.com/ui.js"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.page+" to "+tt.target, func(t *testing.T) {
+			assert.Equal(t, tt.want, relativeURL(tt.page, tt.target))
+		})
+	}
+}
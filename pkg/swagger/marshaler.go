@@ -0,0 +1,66 @@
+package swagger
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// inlineSchema returns the schema of the types which are not described by their fields: the registered types,
+// the SchemaProvider and the marshalers. It returns nil for any other type.
+func (swagger *Swagger) inlineSchema(model any) *openapi3.Schema {
+	if schema := swagger.customSchema(model); schema != nil {
+		return schema
+	}
+
+	return swagger.marshalerSchema(model)
+}
+
+// marshalerSchema follows encoding/json: a json.Marshaler can produce anything so its schema is left open
+// and reported in the warnings, a TextMarshaler is encoded as a string
+func (swagger *Swagger) marshalerSchema(model any) *openapi3.Schema {
+	modelType := reflect.TypeOf(model)
+	if modelType == nil {
+		return nil
+	}
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	// time.Time is a marshaler with a well known format
+	if modelType == timeType {
+		return nil
+	}
+	switch {
+	case implements(modelType, jsonMarshalerType):
+		swagger.warnOnce(modelType, modelType.String()+
+			" implements json.Marshaler, its schema is unknown: implement SchemaProvider or use RegisterType")
+
+		return openapi3.NewSchema()
+	case implements(modelType, textMarshalerType):
+		return openapi3.NewStringSchema()
+	default:
+		return nil
+	}
+}
+
+func implements(modelType, interfaceType reflect.Type) bool {
+	return modelType.Implements(interfaceType) || reflect.PointerTo(modelType).Implements(interfaceType)
+}
+
+func (swagger *Swagger) warnOnce(modelType reflect.Type, warning string) {
+	if swagger.warned[modelType] {
+		return
+	}
+	if swagger.warned == nil {
+		swagger.warned = map[reflect.Type]bool{}
+	}
+	swagger.warned[modelType] = true
+	swagger.Warnings = append(swagger.Warnings, warning)
+}
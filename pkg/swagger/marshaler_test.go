@@ -0,0 +1,85 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Level int
+
+func (level Level) MarshalText() ([]byte, error) {
+	return []byte([]string{"debug", "info"}[level]), nil
+}
+
+type Settings struct {
+	values map[string]string
+}
+
+func (settings Settings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(settings.values)
+}
+
+type Host struct {
+	IP        net.IP    `json:"ip"`
+	Level     Level     `json:"level"`
+	Levels    []Level   `json:"levels"`
+	Balance   *big.Int  `json:"balance"`
+	Settings  Settings  `json:"settings"`
+	Fallback  Settings  `json:"fallback"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func TestSwagger_marshalerSchema(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/hosts", http.MethodGet, nil, router.Responses(router.ResponseMap{
+			"200": {Description: "OK", Model: Host{}},
+		})),
+	})
+	require.NoError(t, err)
+	properties := swag.OpenAPI.Components.Schemas["Host"].Value.Properties
+
+	t.Run("Should describe a TextMarshaler as a string", func(t *testing.T) {
+		assert.Equal(t, "string", properties["ip"].Value.Type)
+		assert.Equal(t, "string", properties["level"].Value.Type)
+		assert.Equal(t, "string", properties["levels"].Value.Items.Value.Type)
+		assert.Equal(t, "string", properties["createdAt"].Value.Type)
+		assert.Equal(t, "date-time", properties["createdAt"].Value.Format)
+	})
+
+	t.Run("Should leave the schema of a json.Marshaler open and warn once", func(t *testing.T) {
+		assert.Empty(t, properties["settings"].Value.Type)
+		assert.Empty(t, properties["settings"].Value.Properties)
+		assert.Empty(t, properties["balance"].Value.Type)
+		assert.NotContains(t, swag.OpenAPI.Components.Schemas, "Settings")
+		assert.Equal(t, []string{
+			"big.Int implements json.Marshaler, its schema is unknown: implement SchemaProvider or use RegisterType",
+			"swagger.Settings implements json.Marshaler, its schema is unknown: implement SchemaProvider or use RegisterType",
+		}, swag.Warnings)
+	})
+
+	t.Run("Should prefer the registered schema of a json.Marshaler", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/settings", http.MethodGet, nil, router.Responses(router.ResponseMap{
+				"200": {Description: "OK", Model: Settings{}},
+			})),
+		}, WithType(reflect.TypeOf(Settings{}), func() *openapi3.Schema {
+			return openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewStringSchema())
+		}))
+		require.NoError(t, err)
+
+		schema := swag.OpenAPI.Paths["/settings"].Get.Responses.Get(http.StatusOK).Value.Content.Get("application/json").Schema
+		assert.Equal(t, "object", schema.Value.Type)
+		assert.Empty(t, swag.Warnings)
+	})
+}
@@ -0,0 +1,168 @@
+package swagger
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// openapi versions of the generated document
+const (
+	OpenAPI30 = "3.0.0"
+	OpenAPI31 = "3.1.0"
+)
+
+const webhooksExtension = "x-webhooks"
+
+// namedMaps are the keys of the maps indexed by user names, e.g. the paths or the status codes of
+// the responses, so a "default" response or an "example" path is never taken for a keyword
+var namedMaps = map[string]bool{
+	"paths":           true,
+	"webhooks":        true,
+	"responses":       true,
+	"content":         true,
+	"headers":         true,
+	"encoding":        true,
+	"parameters":      true,
+	"requestBodies":   true,
+	"securitySchemes": true,
+	"callbacks":       true,
+	"links":           true,
+}
+
+// convertToOpenAPI31 rewrites a 3.0 json document with the json schema 2020-12 semantics of openapi 3.1
+func convertToOpenAPI31(body []byte) ([]byte, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, err
+	}
+	document["openapi"] = OpenAPI31
+	if webhooks, ok := document[webhooksExtension]; ok {
+		document["webhooks"] = webhooks
+		delete(document, webhooksExtension)
+	}
+
+	// the component schemas are converted apart, the generic walk only knows the "schema" keys
+	var schemas map[string]interface{}
+	components, _ := document["components"].(map[string]interface{})
+	if components != nil {
+		schemas, _ = components["schemas"].(map[string]interface{})
+		delete(components, "schemas")
+	}
+	convertNode31(document)
+	for _, schema := range schemas {
+		convertSchema31(schema)
+	}
+	if schemas != nil {
+		components["schemas"] = schemas
+	}
+
+	return json.Marshal(document)
+}
+
+// convertNode31 walks the document looking for the schemas of parameters, headers and media types
+func convertNode31(node interface{}) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			switch {
+			case key == "schema":
+				convertSchema31(child)
+			case key == "example", key == "examples", strings.HasPrefix(key, "x-"):
+				// user values are never converted
+			case namedMaps[key]:
+				convertNamedNodes31(child)
+			default:
+				convertNode31(child)
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			convertNode31(child)
+		}
+	}
+}
+
+// convertNamedNodes31 walks the values of a map indexed by user names, the parameters of an operation
+// are a list instead
+func convertNamedNodes31(node interface{}) {
+	named, ok := node.(map[string]interface{})
+	if !ok {
+		convertNode31(node)
+
+		return
+	}
+	for _, child := range named {
+		convertNode31(child)
+	}
+}
+
+//nolint:gocognit,nolintlint
+func convertSchema31(node interface{}) {
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		convertNullable31(schema)
+	}
+	delete(schema, "nullable")
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []interface{}{example}
+		delete(schema, "example")
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) == 1 {
+		schema["const"] = enum[0]
+		delete(schema, "enum")
+	}
+	convertExclusiveBound31(schema, "exclusiveMinimum", "minimum")
+	convertExclusiveBound31(schema, "exclusiveMaximum", "maximum")
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, property := range properties {
+			convertSchema31(property)
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		convertSchema31(schema[key])
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if schemas, ok := schema[key].([]interface{}); ok {
+			for _, subSchema := range schemas {
+				convertSchema31(subSchema)
+			}
+		}
+	}
+}
+
+// convertNullable31 replaces nullable by a null type, schemas without type become an alternative with null
+func convertNullable31(schema map[string]interface{}) {
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		schema["enum"] = append(enum, nil)
+	}
+	if schemaType, ok := schema["type"].(string); ok {
+		schema["type"] = []interface{}{schemaType, "null"}
+
+		return
+	}
+	nonNullable := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		if key != "nullable" {
+			nonNullable[key] = value
+		}
+		delete(schema, key)
+	}
+	schema["anyOf"] = []interface{}{nonNullable, map[string]interface{}{"type": "null"}}
+}
+
+// convertExclusiveBound31 turns the boolean exclusive bounds of 3.0 into the numeric ones of 3.1
+func convertExclusiveBound31(schema map[string]interface{}, exclusiveKey, boundKey string) {
+	exclusive, ok := schema[exclusiveKey].(bool)
+	if !ok {
+		return
+	}
+	delete(schema, exclusiveKey)
+	if bound, ok := schema[boundKey]; ok && exclusive {
+		schema[exclusiveKey] = bound
+		delete(schema, boundKey)
+	}
+}
@@ -0,0 +1,132 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertToOpenAPI31(t *testing.T) {
+	input := `{
+		"openapi": "3.0.0",
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "nullable": true, "example": 10}}],
+					"responses": {"200": {"description": "ok", "content": {"application/json": {
+						"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+						"example": [{"nullable": true}]
+					}}}}
+				}
+			}
+		},
+		"components": {"schemas": {"Pet": {
+			"type": "object",
+			"properties": {
+				"kind": {"type": "string", "enum": ["dog"]},
+				"color": {"type": "string", "enum": ["red", "blue"], "nullable": true},
+				"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true, "maximum": 30, "exclusiveMaximum": false},
+				"owner": {"allOf": [{"$ref": "#/components/schemas/Owner"}], "nullable": true}
+			}
+		}}},
+		"x-webhooks": {"newPet": {"post": {"responses": {"200": {"description": "ok"}}}}}
+	}`
+
+	body, err := convertToOpenAPI31([]byte(input))
+	require.NoError(t, err)
+
+	var document map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &document))
+	assert.Equal(t, "3.1.0", document["openapi"])
+	assert.Contains(t, document, "webhooks")
+	assert.NotContains(t, document, "x-webhooks")
+
+	get := document["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})
+	limit := get["parameters"].([]interface{})[0].(map[string]interface{})["schema"]
+	assert.Equal(t, map[string]interface{}{
+		"type":     []interface{}{"integer", "null"},
+		"examples": []interface{}{float64(10)},
+	}, limit)
+	mediaType := get["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"nullable": true}}, mediaType["example"])
+
+	properties := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})["Pet"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string", "const": "dog"}, properties["kind"])
+	assert.Equal(t, map[string]interface{}{
+		"type": []interface{}{"string", "null"},
+		"enum": []interface{}{"red", "blue", nil},
+	}, properties["color"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "exclusiveMinimum": float64(0), "maximum": float64(30)}, properties["age"])
+	assert.Equal(t, map[string]interface{}{"anyOf": []interface{}{
+		map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"$ref": "#/components/schemas/Owner"}}},
+		map[string]interface{}{"type": "null"},
+	}}, properties["owner"])
+}
+
+func TestSwagger_openAPIVersion(t *testing.T) {
+	type PetEvent struct {
+		Name string `json:"name"`
+	}
+	webhook := router.New("", http.MethodPost, nil, router.Model(new(PetEvent)), router.Responses(router.ResponseMap{
+		"200": {Description: "received"},
+	}))
+
+	t.Run("Should keep 3.0 by default with the webhooks as extension", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", nil, WithWebhook("newPet", webhook))
+		require.NoError(t, err)
+
+		body, err := swag.MarshalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"openapi":"3.0.0"`)
+		assert.Contains(t, string(body), `"x-webhooks":{"newPet":{"post"`)
+	})
+
+	t.Run("Should generate a 3.1 document", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", nil, WithOpenAPIVersion(OpenAPI31), WithWebhook("newPet", webhook))
+		require.NoError(t, err)
+
+		body, err := swag.MarshalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"openapi":"3.1.0"`)
+		assert.Contains(t, string(body), `"webhooks":{"newPet":{"post"`)
+
+		yaml, err := swag.MarshalYAML()
+		require.NoError(t, err)
+		assert.Contains(t, string(yaml), "openapi: 3.1.0")
+	})
+
+	t.Run("Should convert the schemas of the default response", func(t *testing.T) {
+		// anonymous structs are inlined in the responses instead of referencing a component
+		var problem struct {
+			Detail *string `json:"detail"`
+		}
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/pets", http.MethodGet, nil, router.Responses(router.ResponseMap{
+				"200":     {Description: "ok", Model: problem},
+				"default": {Description: "problem", Model: problem},
+			})),
+		}, WithOpenAPIVersion(OpenAPI31))
+		require.NoError(t, err)
+
+		body, err := swag.MarshalJSON()
+		require.NoError(t, err)
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &document))
+		responses := document["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})
+		schema := func(status string) map[string]interface{} {
+			content := responses[status].(map[string]interface{})["content"].(map[string]interface{})
+
+			return content["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+		}
+
+		nullableString := map[string]interface{}{"type": []interface{}{"string", "null"}}
+		assert.Equal(t, nullableString, schema("200")["properties"].(map[string]interface{})["detail"])
+		assert.Equal(t, nullableString, schema("default")["properties"].(map[string]interface{})["detail"])
+		assert.NotContains(t, string(body), `"nullable"`)
+	})
+}
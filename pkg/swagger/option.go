@@ -0,0 +1,69 @@
+package swagger
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+)
+
+type Option func(swagger *Swagger)
+
+// WithSecurityScheme registers a security scheme in the components of the document
+func WithSecurityScheme(name string, scheme *openapi3.SecurityScheme) Option {
+	return func(swagger *Swagger) {
+		if swagger.SecuritySchemes == nil {
+			swagger.SecuritySchemes = openapi3.SecuritySchemes{}
+		}
+		//nolint:exhaustruct,nolintlint
+		swagger.SecuritySchemes[name] = &openapi3.SecuritySchemeRef{Value: scheme}
+	}
+}
+
+// WithSecurity adds a default security requirement applied to every route which doesn't define its own
+func WithSecurity(name string, scopes ...string) Option {
+	return func(swagger *Swagger) {
+		if scopes == nil {
+			scopes = []string{}
+		}
+		swagger.Security = append(swagger.Security, openapi3.SecurityRequirement{name: scopes})
+	}
+}
+
+// WithOpenAPIVersion selects the version of the generated document, OpenAPI30 or OpenAPI31
+func WithOpenAPIVersion(version string) Option {
+	return func(swagger *Swagger) {
+		swagger.OpenAPIVersion = version
+	}
+}
+
+// WithWebhook describes a request sent by the api to its consumers
+func WithWebhook(name string, route *router.Router) Option {
+	return func(swagger *Swagger) {
+		if swagger.Webhooks == nil {
+			swagger.Webhooks = map[string]*router.Router{}
+		}
+		swagger.Webhooks[name] = route
+	}
+}
+
+// WithType describes a type which can't implement SchemaProvider, see RegisterType
+func WithType(modelType reflect.Type, schema func() *openapi3.Schema) Option {
+	return func(swagger *Swagger) {
+		swagger.registerType(modelType, schema)
+	}
+}
+
+// WithValidateOption teaches the generator a validate tag, see RegisterValidateOption
+func WithValidateOption(name string, option ValidateOption) Option {
+	return func(swagger *Swagger) {
+		swagger.registerValidateOption(name, option)
+	}
+}
+
+// WithSchemaNamer names the component schemas, e.g. PackageSchemaName, TypeSchemaName is the default
+func WithSchemaNamer(namer SchemaNamer) Option {
+	return func(swagger *Swagger) {
+		swagger.SchemaNamer = namer
+	}
+}
@@ -0,0 +1,103 @@
+package swagger
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// pathParameter is a parameter found in the path of a router, pattern holds its regex constraint if any
+type pathParameter struct {
+	name    string
+	pattern string
+}
+
+// parsePath translates the path syntax of gin (/:id, /*path), chi (/{id}, /{id:[0-9]+}) and
+// the go 1.22 ServeMux (/{id}, /{path...}, /{$}) to the openapi one
+func parsePath(path string) (string, []pathParameter) {
+	var (
+		builder    strings.Builder
+		parameters []pathParameter
+	)
+	for i := 0; i < len(path); i++ {
+		char := path[i]
+		switch {
+		case (char == ':' || char == '*') && i > 0 && path[i-1] == '/':
+			end := i + 1
+			for end < len(path) && path[end] != '/' {
+				end++
+			}
+			name := path[i+1 : end]
+			if name == "" {
+				builder.WriteByte(char)
+
+				continue
+			}
+			parameters = append(parameters, pathParameter{name: name, pattern: ""})
+			builder.WriteString("{" + name + "}")
+			i = end - 1
+		case char == '{':
+			end := closingBrace(path, i)
+			if end < 0 {
+				builder.WriteString(path[i:])
+
+				return builder.String(), parameters
+			}
+			name, pattern, _ := strings.Cut(path[i+1:end], ":")
+			name = strings.TrimSuffix(name, "...")
+			i = end
+			if name == "$" {
+				// {$} only anchors the end of a ServeMux pattern
+				continue
+			}
+			if pattern != "" {
+				// the group keeps an alternation such as cat|dog inside the anchors
+				pattern = "^(?:" + pattern + ")$"
+			}
+			parameters = append(parameters, pathParameter{name: name, pattern: pattern})
+			builder.WriteString("{" + name + "}")
+		default:
+			builder.WriteByte(char)
+		}
+	}
+
+	return builder.String(), parameters
+}
+
+// closingBrace returns the index of the brace closing the one at start, the regex of chi may nest braces
+func closingBrace(path string, start int) int {
+	depth := 0
+	for i := start; i < len(path); i++ {
+		switch path[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// withPathParameters declares the path parameters which are missing from the model as strings,
+// every path parameter is required and the string ones get the regex constraint of the router as pattern
+func withPathParameters(parameters openapi3.Parameters, pathParameters []pathParameter) openapi3.Parameters {
+	for _, pathParameter := range pathParameters {
+		parameter := parameters.GetByInAndName(openapi3.ParameterInPath, pathParameter.name)
+		if parameter == nil {
+			parameter = openapi3.NewPathParameter(pathParameter.name).WithSchema(openapi3.NewStringSchema())
+			//nolint:nolintlint,exhaustruct
+			parameters = append(parameters, &openapi3.ParameterRef{Value: parameter})
+		}
+		parameter.Required = true
+		if pathParameter.pattern != "" && parameter.Schema != nil && parameter.Schema.Value != nil &&
+			parameter.Schema.Value.Type == openapi3.TypeString {
+			parameter.Schema.Value.Pattern = pathParameter.pattern
+		}
+	}
+
+	return parameters
+}
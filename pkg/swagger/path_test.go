@@ -0,0 +1,62 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	path, parameters := parsePath("/teams/{team:[a-z]+}/users/:id/{kind:cat|dog}/{file...}")
+
+	assert.Equal(t, "/teams/{team}/users/{id}/{kind}/{file}", path)
+	assert.Equal(t, []pathParameter{
+		{name: "team", pattern: "^(?:[a-z]+)$"},
+		{name: "id"},
+		{name: "kind", pattern: "^(?:cat|dog)$"},
+		{name: "file"},
+	}, parameters)
+}
+
+func TestSwagger_pathParameters(t *testing.T) {
+	type UserRequest struct {
+		ID int `uri:"id"`
+	}
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/users/{id:[0-9]+}/posts/{slug:[a-z-]+}", http.MethodGet, nil, router.Model(UserRequest{})),
+	})
+	require.NoError(t, err)
+	parameters := swag.OpenAPI.Paths["/users/{id}/posts/{slug}"].Get.Parameters
+
+	t.Run("Should require the parameters of the model", func(t *testing.T) {
+		id := parameters.GetByInAndName("path", "id")
+		require.NotNil(t, id)
+		assert.True(t, id.Required)
+		assert.Equal(t, "integer", id.Schema.Value.Type)
+		assert.Empty(t, id.Schema.Value.Pattern)
+	})
+
+	t.Run("Should declare the parameters missing from the model with their pattern", func(t *testing.T) {
+		slug := parameters.GetByInAndName("path", "slug")
+		require.NotNil(t, slug)
+		assert.True(t, slug.Required)
+		assert.Equal(t, "string", slug.Schema.Value.Type)
+		assert.Equal(t, "^(?:[a-z-]+)$", slug.Schema.Value.Pattern)
+	})
+}
+
+func TestPathParameterPattern(t *testing.T) {
+	_, parameters := parsePath("/pets/{kind:cat|dog}")
+	require.Len(t, parameters, 1)
+	pattern := regexp.MustCompile(parameters[0].pattern)
+
+	assert.True(t, pattern.MatchString("cat"))
+	assert.True(t, pattern.MatchString("dog"))
+	assert.False(t, pattern.MatchString("catfish"))
+	assert.False(t, pattern.MatchString("hotdog"))
+}
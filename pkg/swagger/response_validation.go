@@ -0,0 +1,158 @@
+package swagger
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/pkg/errors"
+)
+
+// ResponseReporter receives the responses which don't match the specification
+type ResponseReporter func(request *http.Request, err error)
+
+// ResponseValidator returns a net/http middleware checking the status code, the headers and the body
+// of each response against the router responses. The response is sent unchanged to the client,
+// the mismatches go to report, e.g. a logger on staging or t.Error in the integration tests.
+func (swagger *Swagger) ResponseValidator(report ResponseReporter) (func(http.Handler) http.Handler, error) {
+	finder, err := swagger.routeFinder()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			route, pathParams, err := finder.FindRoute(request)
+			if err != nil {
+				next.ServeHTTP(writer, request)
+
+				return
+			}
+			recorder := &responseRecorder{ResponseWriter: writer, status: http.StatusOK, body: bytes.Buffer{}}
+			next.ServeHTTP(recorder, request)
+			if err := validateResponse(request, route, pathParams, recorder.status, writer.Header(), recorder.body.Bytes()); err != nil {
+				report(request, err)
+			}
+			writer.WriteHeader(recorder.status)
+			_, _ = writer.Write(recorder.body.Bytes())
+		})
+	}, nil
+}
+
+// ValidateResponse checks a response against the specification, e.g. the result of an httptest.ResponseRecorder
+func (swagger *Swagger) ValidateResponse(request *http.Request, response *http.Response) error {
+	finder, err := swagger.routeFinder()
+	if err != nil {
+		return err
+	}
+	route, pathParams, err := finder.FindRoute(request)
+	if err != nil {
+		return errors.Wrap(ErrUndocumentedRoute, request.Method+" "+request.URL.Path)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	return validateResponse(request, route, pathParams, response.StatusCode, response.Header, body)
+}
+
+func validateResponse(
+	request *http.Request,
+	route *routers.Route,
+	pathParams map[string]string,
+	status int,
+	header http.Header,
+	body []byte,
+) error {
+	//nolint:exhaustruct,nolintlint
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: status,
+		Header: header,
+		Body:   io.NopCloser(bytes.NewReader(body)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+		},
+	}
+	err := openapi3filter.ValidateResponse(request.Context(), input)
+	if err == nil {
+		err = validateResponseHeaders(route.Operation.Responses.Get(status), header)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "%s %s responded %d", request.Method, route.Path, status)
+	}
+
+	return nil
+}
+
+// validateResponseHeaders checks the documented headers, openapi3filter only validates the body
+func validateResponseHeaders(response *openapi3.ResponseRef, header http.Header) error {
+	if response == nil || response.Value == nil {
+		return nil
+	}
+	for name, headerRef := range response.Value.Headers {
+		if headerRef.Value == nil {
+			continue
+		}
+		value := header.Get(name)
+		if value == "" {
+			if headerRef.Value.Required {
+				return errors.Wrap(ErrMissingResponseHeader, name)
+			}
+
+			continue
+		}
+		if headerRef.Value.Schema == nil || headerRef.Value.Schema.Value == nil {
+			continue
+		}
+		schema := headerRef.Value.Schema.Value
+		if err := schema.VisitJSON(headerValue(schema, value)); err != nil {
+			return errors.Wrapf(err, "response header %s", name)
+		}
+	}
+
+	return nil
+}
+
+// headerValue decodes a header to the type of its schema, a value which doesn't parse stays a string
+// so the schema reports it
+func headerValue(schema *openapi3.Schema, value string) interface{} {
+	switch schema.Type {
+	case openapi3.TypeInteger, openapi3.TypeNumber:
+		if number, err := strconv.ParseFloat(value, BITSIZE); err == nil {
+			return number
+		}
+	case openapi3.TypeBoolean:
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}
+
+// responseRecorder holds the status and the body until the response is validated
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (recorder *responseRecorder) WriteHeader(status int) {
+	recorder.status = status
+}
+
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	return recorder.body.Write(data)
+}
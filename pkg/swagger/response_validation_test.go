@@ -0,0 +1,102 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger_ResponseValidator(t *testing.T) {
+	type Pet struct {
+		Name string `json:"name" validate:"required"`
+		Age  int    `json:"age"`
+	}
+	rateLimit := openapi3.NewHeaderParameter("X-Rate-Limit").WithRequired(true).WithSchema(openapi3.NewIntegerSchema())
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/pets", http.MethodGet, nil, router.Responses(router.ResponseMap{
+			"200": {
+				Description: "pets",
+				Model:       []Pet{},
+				Headers:     openapi3.Headers{"X-Rate-Limit": {Value: &openapi3.Header{Parameter: *rateLimit}}},
+			},
+			"204": {Description: "no pet"},
+		})),
+	})
+	require.NoError(t, err)
+
+	serve := func(status int, header map[string]string, body string) (*httptest.ResponseRecorder, []error) {
+		var reported []error
+		middleware, err := swag.ResponseValidator(func(_ *http.Request, err error) {
+			reported = append(reported, err)
+		})
+		require.NoError(t, err)
+		handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			for key, value := range header {
+				writer.Header().Set(key, value)
+			}
+			writer.WriteHeader(status)
+			_, _ = writer.Write([]byte(body))
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+
+		return rec, reported
+	}
+	jsonHeader := map[string]string{"Content-Type": "application/json", "X-Rate-Limit": "10"}
+
+	t.Run("Should not report a documented response", func(t *testing.T) {
+		rec, reported := serve(http.StatusOK, jsonHeader, `[{"name":"rex","age":3}]`)
+
+		assert.Empty(t, reported)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `[{"name":"rex","age":3}]`, rec.Body.String())
+	})
+
+	t.Run("Should not report an empty documented response", func(t *testing.T) {
+		_, reported := serve(http.StatusNoContent, nil, "")
+
+		assert.Empty(t, reported)
+	})
+
+	t.Run("Should report an undocumented status code", func(t *testing.T) {
+		rec, reported := serve(http.StatusTeapot, nil, "")
+
+		require.Len(t, reported, 1)
+		assert.Contains(t, reported[0].Error(), "GET /pets responded 418")
+		assert.Contains(t, reported[0].Error(), "status is not supported")
+		assert.Equal(t, http.StatusTeapot, rec.Code)
+	})
+
+	t.Run("Should report a body which doesn't match the schema", func(t *testing.T) {
+		rec, reported := serve(http.StatusOK, jsonHeader, `[{"age":"old"}]`)
+
+		require.Len(t, reported, 1)
+		assert.Contains(t, reported[0].Error(), "response body doesn't match")
+		assert.Equal(t, `[{"age":"old"}]`, rec.Body.String())
+	})
+
+	t.Run("Should report the headers which don't match", func(t *testing.T) {
+		_, reported := serve(http.StatusOK, map[string]string{"Content-Type": "application/json"}, `[]`)
+		require.Len(t, reported, 1)
+		assert.ErrorIs(t, reported[0], ErrMissingResponseHeader)
+
+		_, reported = serve(http.StatusOK, map[string]string{"Content-Type": "application/json", "X-Rate-Limit": "many"}, `[]`)
+		require.Len(t, reported, 1)
+		assert.Contains(t, reported[0].Error(), "X-Rate-Limit")
+	})
+
+	t.Run("Should validate a recorded response", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/pets", nil)
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusInternalServerError)
+
+		require.Error(t, swag.ValidateResponse(request, rec.Result()))
+		require.ErrorIs(t, swag.ValidateResponse(httptest.NewRequest(http.MethodGet, "/cats", nil), rec.Result()), ErrUndocumentedRoute)
+	})
+}
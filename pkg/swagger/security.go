@@ -0,0 +1,68 @@
+package swagger
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// security scheme types
+const (
+	SecurityTypeHTTP          = "http"
+	SecurityTypeAPIKey        = "apiKey"
+	SecurityTypeOAuth2        = "oauth2"
+	SecurityTypeOpenIDConnect = "openIdConnect"
+)
+
+// BearerSecurityScheme describes an Authorization: Bearer header, bearerFormat is a hint such as JWT
+func BearerSecurityScheme(bearerFormat string) *openapi3.SecurityScheme {
+	return openapi3.NewSecurityScheme().
+		WithType(SecurityTypeHTTP).
+		WithScheme("bearer").
+		WithBearerFormat(bearerFormat)
+}
+
+// JWTSecurityScheme describes a bearer token in the JWT format
+func JWTSecurityScheme() *openapi3.SecurityScheme {
+	return BearerSecurityScheme("JWT")
+}
+
+// BasicSecurityScheme describes the http basic authentication
+func BasicSecurityScheme() *openapi3.SecurityScheme {
+	return openapi3.NewSecurityScheme().
+		WithType(SecurityTypeHTTP).
+		WithScheme("basic")
+}
+
+// APIKeySecurityScheme describes an api key sent in a header, a query parameter or a cookie
+func APIKeySecurityScheme(in, name string) *openapi3.SecurityScheme {
+	return openapi3.NewSecurityScheme().
+		WithType(SecurityTypeAPIKey).
+		WithIn(in).
+		WithName(name)
+}
+
+// OAuth2SecurityScheme describes the oauth2 flows supported by the api
+func OAuth2SecurityScheme(flows *openapi3.OAuthFlows) *openapi3.SecurityScheme {
+	scheme := openapi3.NewSecurityScheme().WithType(SecurityTypeOAuth2)
+	scheme.Flows = flows
+
+	return scheme
+}
+
+// OpenIDConnectSecurityScheme describes an openid connect provider from its discovery url
+func OpenIDConnectSecurityScheme(url string) *openapi3.SecurityScheme {
+	return openapi3.NewOIDCSecurityScheme(url)
+}
+
+// checkSecurity ensures each requirement refers to a registered security scheme
+func (swagger *Swagger) checkSecurity(requirements openapi3.SecurityRequirements) error {
+	for _, requirement := range requirements {
+		for name := range requirement {
+			if _, ok := swagger.SecuritySchemes[name]; !ok {
+				return errors.Wrap(ErrUnknownSecurityScheme, name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger_security(t *testing.T) {
+	routers := []*router.Router{
+		router.New("/me", http.MethodGet, nil),
+		router.New("/admin", http.MethodGet, nil, router.Security("oauth", "admin")),
+		router.New("/health", http.MethodGet, nil, router.NoSecurity()),
+	}
+
+	t.Run("Should register the security schemes and requirements", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", routers,
+			WithSecurityScheme("bearer", JWTSecurityScheme()),
+			WithSecurityScheme("apiKey", APIKeySecurityScheme(openapi3.ParameterInHeader, "X-API-Key")),
+			WithSecurityScheme("basic", BasicSecurityScheme()),
+			WithSecurityScheme("oidc", OpenIDConnectSecurityScheme("https://example.com/.well-known/openid-configuration")),
+			WithSecurityScheme("oauth", OAuth2SecurityScheme(&openapi3.OAuthFlows{
+				ClientCredentials: &openapi3.OAuthFlow{
+					TokenURL: "https://example.com/token",
+					Scopes:   map[string]string{"admin": "administration"},
+				},
+			})),
+			WithSecurity("bearer"),
+		)
+		require.NoError(t, err)
+		require.NoError(t, swag.OpenAPI.Components.Validate(context.Background()))
+
+		require.Len(t, swag.OpenAPI.Components.SecuritySchemes, 5)
+		assert.Equal(t, "bearer", swag.OpenAPI.Components.SecuritySchemes["bearer"].Value.Scheme)
+		assert.Equal(t, "JWT", swag.OpenAPI.Components.SecuritySchemes["bearer"].Value.BearerFormat)
+		assert.Equal(t, openapi3.SecurityRequirements{{"bearer": []string{}}}, swag.OpenAPI.Security)
+
+		assert.Nil(t, swag.OpenAPI.Paths["/me"].Get.Security)
+		assert.Equal(t, &openapi3.SecurityRequirements{{"oauth": []string{"admin"}}}, swag.OpenAPI.Paths["/admin"].Get.Security)
+		assert.Equal(t, &openapi3.SecurityRequirements{}, swag.OpenAPI.Paths["/health"].Get.Security)
+
+		body, err := swag.MarshalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"security":[]`)
+	})
+
+	t.Run("Should fail on an unknown security scheme", func(t *testing.T) {
+		_, err := New("foo", "bar", "1.0.0", routers, WithSecurityScheme("bearer", JWTSecurityScheme()))
+
+		require.ErrorIs(t, err, ErrUnknownSecurityScheme)
+		assert.Contains(t, err.Error(), "oauth")
+	})
+
+	t.Run("Should fail on an unknown default security scheme", func(t *testing.T) {
+		_, err := New("foo", "bar", "1.0.0", nil, WithSecurity("bearer"))
+
+		require.ErrorIs(t, err, ErrUnknownSecurityScheme)
+	})
+}
@@ -2,20 +2,37 @@
 package swagger
 
 import (
+	"io"
 	"mime/multipart"
 	"net/http"
 	"reflect"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/fatih/structtag"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/invopop/yaml"
+	"github.com/pkg/errors"
 )
 
 var (
-	fixPathRe = regexp.MustCompile(`/:(\w+)`)
+	basicTypes = map[reflect.Kind]reflect.Type{
+		reflect.Bool:    reflect.TypeOf(false),
+		reflect.Int:     reflect.TypeOf(int(0)),
+		reflect.Int8:    reflect.TypeOf(int8(0)),
+		reflect.Int16:   reflect.TypeOf(int16(0)),
+		reflect.Int32:   reflect.TypeOf(int32(0)),
+		reflect.Int64:   reflect.TypeOf(int64(0)),
+		reflect.Uint:    reflect.TypeOf(uint(0)),
+		reflect.Uint8:   reflect.TypeOf(uint8(0)),
+		reflect.Uint16:  reflect.TypeOf(uint16(0)),
+		reflect.Uint32:  reflect.TypeOf(uint32(0)),
+		reflect.Uint64:  reflect.TypeOf(uint64(0)),
+		reflect.Float32: reflect.TypeOf(float32(0)),
+		reflect.Float64: reflect.TypeOf(float64(0)),
+		reflect.String:  reflect.TypeOf(""),
+	}
 )
 
 // tag attribute
@@ -29,13 +46,14 @@ const (
 
 // binding attributes
 const (
-	QUERY    = "query"
-	FORM     = "form"
-	URI      = "uri"
-	HEADER   = "header"
-	COOKIE   = "cookie"
-	JSON     = "json"
-	REQUIRED = "required"
+	QUERY     = "query"
+	FORM      = "form"
+	URI       = "uri"
+	HEADER    = "header"
+	COOKIE    = "cookie"
+	JSON      = "json"
+	OMITEMPTY = "omitempty"
+	REQUIRED  = "required"
 )
 
 const (
@@ -50,6 +68,9 @@ type Swagger struct {
 	DocsURL         string
 	RedocURL        string
 	OpenAPIURL      string
+	OpenAPIYAMLURL  string
+	AssetsURL       string
+	AssetsSource    AssetsSource
 	Routers         []*router.Router
 	Servers         openapi3.Servers
 	TermsOfService  string
@@ -58,26 +79,56 @@ type Swagger struct {
 	OpenAPI         *openapi3.T
 	SwaggerOptions  map[string]interface{}
 	RedocOptions    map[string]interface{}
-	validateOptions []validateOption
+	SchemaNamer     SchemaNamer
+	SecuritySchemes openapi3.SecuritySchemes
+	Security        openapi3.SecurityRequirements
+	OpenAPIVersion  string
+	Webhooks        map[string]*router.Router
+	// Warnings lists the types whose schema couldn't be inferred
+	Warnings        []string
+	validateOptions []builtinValidateOption
+	schemas         openapi3.Schemas
+	schemaTypes     map[reflect.Type]string
+	visiting        map[reflect.Type]bool
+	customTypes     map[reflect.Type]func() *openapi3.Schema
+	warned          map[reflect.Type]bool
+	customOptions   map[string]ValidateOption
+	fieldErrors     []error
+	// decoding is set while describing the values decoded by the api, e.g. the request bodies
+	decoding            bool
+	decodingSchemaTypes map[reflect.Type]string
+	decodingVariants    map[reflect.Type]bool
 }
 
-func New(title, description, version string, routers []*router.Router) (*Swagger, error) {
+func New(title, description, version string, routers []*router.Router, options ...Option) (*Swagger, error) {
 	//nolint:exhaustruct,nolintlint
 	swagger := &Swagger{
-		Title:       title,
-		Description: description,
-		Version:     version,
-		DocsURL:     "/docs",
-		RedocURL:    "/redoc",
-		OpenAPIURL:  "/openapi.json",
-		Routers:     routers,
-		validateOptions: []validateOption{
+		Title:          title,
+		Description:    description,
+		Version:        version,
+		DocsURL:        "/docs",
+		RedocURL:       "/redoc",
+		OpenAPIURL:     "/openapi.json",
+		OpenAPIYAMLURL: "/openapi.yaml",
+		AssetsURL:      "/docs/assets",
+		OpenAPIVersion: OpenAPI30,
+		Routers:        routers,
+		validateOptions: []builtinValidateOption{
 			validateLenOption,
 			validateEnumOption,
 			validateMaxOption,
 			validateMinOption,
+			validateComparisonOption,
+			validateOneOfOption,
+			validateEqualOption,
+			validateFormatOption,
+			validatePatternOption,
+			validateUniqueOption,
 		},
 	}
+	for _, opt := range options {
+		opt(swagger)
+	}
 	if err := swagger.buildOpenAPI(); err != nil {
 		return nil, err
 	}
@@ -87,14 +138,27 @@ func New(title, description, version string, routers []*router.Router) (*Swagger
 
 func (swagger *Swagger) buildOpenAPI() error {
 
-	var paths openapi3.Paths
+	var paths, webhooks openapi3.Paths
 	var err error
 
+	swagger.schemas = openapi3.Schemas{}
+	swagger.schemaTypes = map[reflect.Type]string{}
+	swagger.decodingSchemaTypes = map[reflect.Type]string{}
+	swagger.decodingVariants = map[reflect.Type]bool{}
+	swagger.Warnings = nil
+	swagger.warned = map[reflect.Type]bool{}
+	swagger.fieldErrors = nil
 	components := openapi3.NewComponents()
 	components.SecuritySchemes = openapi3.SecuritySchemes{}
+	for name, scheme := range swagger.SecuritySchemes {
+		components.SecuritySchemes[name] = scheme
+	}
+	if err := swagger.checkSecurity(swagger.Security); err != nil {
+		return err
+	}
 	//nolint:exhaustruct,nolintlint
 	swagger.OpenAPI = &openapi3.T{
-		OpenAPI: "3.0.0",
+		OpenAPI: OpenAPI30,
 		Info: &openapi3.Info{
 			Title:          swagger.Title,
 			Description:    swagger.Description,
@@ -105,12 +169,24 @@ func (swagger *Swagger) buildOpenAPI() error {
 		},
 		Servers:    swagger.Servers,
 		Components: components,
+		Security:   swagger.Security,
 	}
 
 	if paths, err = swagger.paths(); err != nil {
 		return err
 	}
 	swagger.OpenAPI.Paths = paths
+	if webhooks, err = swagger.webhooks(); err != nil {
+		return err
+	}
+	if len(webhooks) > 0 {
+		// openapi 3.0 has no webhooks, the x-webhooks extension is understood by redoc
+		swagger.OpenAPI.Extensions = map[string]interface{}{webhooksExtension: webhooks}
+	}
+	swagger.OpenAPI.Components.Schemas = swagger.schemas
+	if len(swagger.fieldErrors) > 0 {
+		return swagger.fieldErrors[0]
+	}
 
 	return nil
 }
@@ -119,30 +195,69 @@ func (swagger *Swagger) paths() (openapi3.Paths, error) {
 	paths := make(openapi3.Paths)
 	var ok bool
 	for _, router := range swagger.Routers {
-		path := swagger.sanitizePath(router.Path)
+		path, pathParameters := parsePath(router.Path)
 		if _, ok = paths[path]; !ok {
 			paths[path] = &openapi3.PathItem{} //nolint:exhaustruct,nolintlint
 		}
-		parameters, err := swagger.parametersFromModel(router.Model)
+		operation, err := swagger.operation(router, false)
 		if err != nil {
 			return nil, err
 		}
-		//nolint:exhaustruct,nolintlint
-		operation := &openapi3.Operation{
-			Tags:        router.Tags,
-			OperationID: router.OperationID,
-			Summary:     router.Summary,
-			Description: router.Description,
-			Deprecated:  router.Deprecated,
-			Responses:   swagger.responses(router.Responses, router.ResponseContentType),
-			Parameters:  parameters,
-		}
+		operation.Parameters = withPathParameters(operation.Parameters, pathParameters)
 		swagger.addPath(paths, router.Method, path, operation)
 	}
 
 	return paths, nil
 }
 
+// webhooks describes the requests sent by the api, they are indexed by name instead of path
+func (swagger *Swagger) webhooks() (openapi3.Paths, error) {
+	webhooks := make(openapi3.Paths)
+	// the order of the walk names the colliding component schemas, it must not change between builds
+	for _, name := range sortedKeys(swagger.Webhooks) {
+		router := swagger.Webhooks[name]
+		operation, err := swagger.operation(router, true)
+		if err != nil {
+			return nil, err
+		}
+		webhooks[name] = &openapi3.PathItem{} //nolint:exhaustruct,nolintlint
+		swagger.addPath(webhooks, router.Method, name, operation)
+	}
+
+	return webhooks, nil
+}
+
+// operation describes a router, the api decodes the request bodies and encodes the responses except for
+// the webhooks which it sends
+func (swagger *Swagger) operation(router *router.Router, webhook bool) (*openapi3.Operation, error) {
+	parameters, err := swagger.parametersFromModel(router.Model)
+	if err != nil {
+		return nil, err
+	}
+	requestBody, err := swagger.requestBody(router, !webhook)
+	if err != nil {
+		return nil, err
+	}
+	if router.Security != nil {
+		if err := swagger.checkSecurity(*router.Security); err != nil {
+			return nil, err
+		}
+	}
+
+	//nolint:exhaustruct,nolintlint
+	return &openapi3.Operation{
+		Tags:        router.Tags,
+		OperationID: router.OperationID,
+		Summary:     router.Summary,
+		Description: router.Description,
+		Deprecated:  router.Deprecated,
+		Responses:   swagger.responses(router.Responses, router.ResponseContentType, webhook),
+		Parameters:  parameters,
+		RequestBody: requestBody,
+		Security:    router.Security,
+	}, nil
+}
+
 func (swagger *Swagger) addPath(paths openapi3.Paths, method, path string, operation *openapi3.Operation) {
 	method = strings.ToUpper(method)
 	switch method {
@@ -167,12 +282,76 @@ func (swagger *Swagger) addPath(paths openapi3.Paths, method, path string, opera
 	}
 }
 
+// requestBody builds the body of the write operations from the model fields which are not parameters.
+// Json fields are used by default, form fields when the request content type is a form.
+func (swagger *Swagger) requestBody(route *router.Router, decoding bool) (*openapi3.RequestBodyRef, error) {
+	if route.Model == nil || !hasRequestBody(route.Method) {
+		return nil, nil //nolint:nilnil,nolintlint
+	}
+	defer swagger.describeDecoded(decoding)()
+	contentType := route.RequestContentType
+	if contentType == "" {
+		contentType = router.MIMEApplicationJSON
+	}
+	bindingTag := JSON
+	if isFormContentType(contentType) {
+		bindingTag = FORM
+	}
+	schema, err := swagger.requestBodySchema(route.Model, bindingTag)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Type == openapi3.TypeObject && len(schema.Properties) == 0 {
+		return nil, nil //nolint:nilnil,nolintlint
+	}
+	mediaType := openapi3.NewMediaType().WithSchema(schema)
+	if bindingTag == FORM {
+		for name, property := range schema.Properties {
+			//nolint:exhaustruct,nolintlint
+			mediaType.WithEncoding(name, &openapi3.Encoding{ContentType: formEncodingContentType(property.Value)})
+		}
+	}
+	requestBody := openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.Content{contentType: mediaType})
+
+	//nolint:exhaustruct,nolintlint
+	return &openapi3.RequestBodyRef{Value: requestBody}, nil
+}
+
+func (swagger *Swagger) requestBodySchema(model any, bindingTag string) (*openapi3.Schema, error) {
+	modelType, modelValue := swagger.typeAndValue(model)
+	if modelType.Kind() != reflect.Struct {
+		return swagger.schemaFromModel(model), nil
+	}
+	schema := openapi3.NewObjectSchema()
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		tags, err := structtag.Parse(string(field.Tag))
+		if err != nil {
+			return nil, err
+		}
+		if isParameter(tags) {
+			continue
+		}
+		if err := swagger.propertyFromReflectStruct(modelType, modelValue.Field(i), field, schema, bindingTag); err != nil {
+			continue
+		}
+	}
+
+	return schema, nil
+}
+
 func (swagger *Swagger) parametersFromModel(model interface{}) (openapi3.Parameters, error) {
 	parameters := openapi3.NewParameters()
 	if model == nil {
 		return parameters, nil
 	}
 	modelType, modelValue := swagger.typeAndValue(model)
+	if modelType.Kind() != reflect.Struct {
+		// a list or a map model is a body without parameters
+		return parameters, nil
+	}
 	for i := 0; i < modelType.NumField(); i++ {
 		field := modelType.Field(i)
 		value := modelValue.Field(i)
@@ -191,7 +370,8 @@ func (swagger *Swagger) parametersFromModel(model interface{}) (openapi3.Paramet
 			Schema: openapi3.NewSchemaRef("", swagger.schemaFromType(value.Interface())),
 		}
 
-		if params, err := swagger.parseQueryFromTags(tags, parameter, value, parameters); err == nil {
+		path := fieldPath(modelType, field)
+		if params, err := swagger.parseQueryFromTags(tags, parameter, value, path, parameters); err == nil {
 			parameters = params
 		}
 	}
@@ -203,6 +383,7 @@ func (swagger *Swagger) parseQueryFromTags(
 	tags *structtag.Tags,
 	parameter *openapi3.Parameter,
 	value reflect.Value,
+	path string,
 	parameters openapi3.Parameters,
 ) (openapi3.Parameters, error) {
 	parseTagQuery(tags, parameter)
@@ -216,21 +397,15 @@ func (swagger *Swagger) parseQueryFromTags(
 	parseTagDescription(tags, parameter)
 	validateTag, err := tags.Get(VALIDATE)
 	if err == nil {
-		parameter.WithRequired(validateTag.Name == REQUIRED)
-		options := validateTag.Options
-		if len(options) > 0 {
-			if schema, err := swagger.validateSchema(value.Interface(), options); err == nil {
-				parameter.Schema = schema
-			}
+		parameter.WithRequired(hasRequiredRule(tags))
+		if schema, err := swagger.validateSchema(value.Interface(), validateRules(validateTag)); err == nil {
+			parameter.Schema = schema
+		} else {
+			swagger.fieldError(path, err)
 		}
 	}
-	defaultTag, err := tags.Get(DEFAULT)
-	if err == nil {
-		parameter.Schema.Value.WithDefault(defaultTag.Name)
-	}
-	exampleTag, err := tags.Get(EXAMPLE)
-	if err == nil {
-		parameter.Schema.Value.Example = exampleTag.Name
+	if err := parseValueTags(tags, parameter.Schema.Value); err != nil {
+		swagger.fieldError(path, err)
 	}
 
 	//nolint:nolintlint,exhaustruct
@@ -241,6 +416,46 @@ func (swagger *Swagger) parseQueryFromTags(
 	return parameters, nil
 }
 
+func hasRequestBody(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFormContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, router.MIMEApplicationForm) ||
+		strings.HasPrefix(contentType, router.MIMEMultipartForm)
+}
+
+// formEncodingContentType returns the content type of a form property: files are sent as binary,
+// objects as json and scalars as plain text. An array is a repeated field, its items decide.
+func formEncodingContentType(schema *openapi3.Schema) string {
+	if schema.Type == openapi3.TypeArray && schema.Items != nil && schema.Items.Value != nil {
+		schema = schema.Items.Value
+	}
+	switch {
+	case schema.Format == "binary":
+		return router.MIMEOctetStream
+	case schema.Type == openapi3.TypeObject:
+		return router.MIMEApplicationJSON
+	default:
+		return router.MIMETextPlain
+	}
+}
+
+func isParameter(tags *structtag.Tags) bool {
+	for _, key := range []string{QUERY, URI, HEADER, COOKIE} {
+		if _, err := tags.Get(key); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (*Swagger) typeAndValue(model interface{}) (reflect.Type, reflect.Value) {
 	modelType := reflect.TypeOf(model)
 	if modelType.Kind() == reflect.Ptr {
@@ -248,6 +463,10 @@ func (*Swagger) typeAndValue(model interface{}) (reflect.Type, reflect.Value) {
 	}
 	modelValue := reflect.ValueOf(model)
 	if modelValue.Kind() == reflect.Ptr {
+		if modelValue.IsNil() {
+			// nested pointers are nil, their zero value is enough to walk the type
+			return modelType, reflect.New(modelType).Elem()
+		}
 		modelValue = modelValue.Elem()
 	}
 
@@ -256,37 +475,89 @@ func (*Swagger) typeAndValue(model interface{}) (reflect.Type, reflect.Value) {
 
 func (swagger *Swagger) validateSchema(value interface{}, options []string) (*openapi3.SchemaRef, error) {
 	schema := openapi3.NewSchemaRef("", swagger.schemaFromType(value))
-	for _, option := range options {
+	if err := swagger.applyValidateRules(schema, reflect.TypeOf(value), options); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
 
+// applyValidateRules adds the constraints of the validate rules to the schema, the rules following
+// a dive apply to the items of an array or to the values of a map
+func (swagger *Swagger) applyValidateRules(schema *openapi3.SchemaRef, fieldType reflect.Type, rules []string) error {
+	target := schema
+	inKeys := false
+	dives := 0
+	for _, rule := range rules {
+		switch {
+		case rule == KeysOption, rule == EndKeysOption:
+			// the constraints of the map keys can't be described
+			inKeys = rule == KeysOption
+
+			continue
+		case inKeys, strings.Contains(rule, "|"), rule == REQUIRED, rule == OMITEMPTY:
+			continue
+		case rule == DiveOption:
+			dives++
+
+			continue
+		}
+		// the items are only wrapped when a rule constrains them
+		for ; dives > 0; dives-- {
+			if target = diveSchema(target); target == nil {
+				return nil
+			}
+			fieldType = elemType(fieldType)
+		}
+		name, param, _ := strings.Cut(rule, "=")
+		if option, ok := swagger.customOptions[name]; ok {
+			if err := option(fieldType, target.Value, param); err != nil {
+				return errors.Wrap(err, rule)
+			}
+
+			continue
+		}
 		for _, validateFunc := range swagger.validateOptions {
-			if err := validateFunc(schema, option); err != nil {
-				return nil, err
+			if err := validateFunc(target, rule); err != nil {
+				return err
 			}
 		}
 	}
 
-	return schema, nil
+	return nil
 }
 
-func (swagger *Swagger) responses(responses map[string]*router.Response, contentType string) openapi3.Responses {
+func (swagger *Swagger) responses(
+	responses map[string]*router.Response,
+	contentType string,
+	decoding bool,
+) openapi3.Responses {
+	defer swagger.describeDecoded(decoding)()
 	resp := make(openapi3.Responses)
-	for statusCode, response := range responses {
-		schema := swagger.schemaFromModel(response.Model)
+	for _, statusCode := range sortedKeys(responses) {
+		response := responses[statusCode]
 		description := response.Description
 		//nolint:exhaustruct,nolintlint
 		resp[statusCode] = &openapi3.ResponseRef{
 			Value: &openapi3.Response{
 				Description: &description,
 				Headers:     response.Headers,
-				Content:     openapi3.NewContentWithSchema(schema, []string{contentType}),
 			},
 		}
+		// a response without model has no body, e.g. 204 No Content
+		if response.Model != nil {
+			schema := swagger.schemaRefFromModel(response.Model)
+			resp[statusCode].Value.Content = openapi3.NewContentWithSchemaRef(schema, []string{contentType})
+		}
 	}
 
 	return resp
 }
 
 func (swagger *Swagger) schemaFromType(model any) *openapi3.Schema {
+	if schema := swagger.inlineSchema(model); schema != nil {
+		return withEnum(schema, model)
+	}
 	var schema *openapi3.Schema
 	var min float64 = 0
 
@@ -332,7 +603,7 @@ func (swagger *Swagger) schemaFromType(model any) *openapi3.Schema {
 		schema = swagger.schemaFromModel(model)
 	}
 
-	return schema
+	return withEnum(schema, model)
 }
 
 func (swagger *Swagger) schemaFromModel(model any) *openapi3.Schema {
@@ -342,6 +613,15 @@ func (swagger *Swagger) schemaFromModel(model any) *openapi3.Schema {
 	}
 
 	modelType, modelValue := swagger.typeAndValue(model)
+	if swagger.visiting[modelType] {
+		// a cycle which doesn't go through a component schema can't be referenced
+		return openapi3.NewSchema()
+	}
+	if swagger.visiting == nil {
+		swagger.visiting = map[reflect.Type]bool{}
+	}
+	swagger.visiting[modelType] = true
+	defer delete(swagger.visiting, modelType)
 
 	//nolint:exhaustive,nolintlint
 	switch modelType.Kind() {
@@ -349,32 +629,55 @@ func (swagger *Swagger) schemaFromModel(model any) *openapi3.Schema {
 		for i := 0; i < modelType.NumField(); i++ {
 			field := modelType.Field(i)
 			value := modelValue.Field(i)
-			if err := swagger.schemaFromReflectStruct(value, field, schema); err != nil {
+			if err := swagger.schemaFromReflectStruct(modelType, value, field, schema); err != nil {
 				continue
 			}
 		}
 	case reflect.Slice:
 		schema = openapi3.NewArraySchema()
 
-		//nolint:exhaustruct,nolintlint
-		schema.Items = &openapi3.SchemaRef{
-			Value: swagger.schemaFromModel(reflect.New(modelType.Elem()).Elem().Interface()),
-		}
+		schema.Items = swagger.schemaRefFromType(reflect.New(modelType.Elem()).Elem().Interface())
 	case reflect.Map:
 		schema = openapi3.NewObjectSchema()
+		if modelType.Elem().Kind() == reflect.Interface {
+			schema.AdditionalPropertiesAllowed = openapi3.BoolPtr(true)
+		} else {
+			schema.AdditionalProperties = swagger.schemaRefFromType(reflect.New(modelType.Elem()).Elem().Interface())
+		}
 	default:
-		schema = swagger.schemaFromType(model)
+		schema = swagger.schemaFromType(underlyingValue(modelValue))
 	}
 
 	return schema
 }
 
+// underlyingValue converts the values of named basic types, e.g. type Status string,
+// to their predeclared type so schemaFromType recognizes them
+func underlyingValue(value reflect.Value) any {
+	if basicType, ok := basicTypes[value.Kind()]; ok && value.Type() != basicType {
+		return value.Convert(basicType).Interface()
+	}
+
+	return value.Interface()
+}
+
 func (swagger *Swagger) schemaFromReflectStruct(
+	owner reflect.Type,
+	value reflect.Value,
+	field reflect.StructField,
+	schema *openapi3.Schema,
+) error {
+	return swagger.propertyFromReflectStruct(owner, value, field, schema, JSON)
+}
+
+// propertyFromReflectStruct adds the field to the schema properties under the name of its binding tag
+func (swagger *Swagger) propertyFromReflectStruct(
+	owner reflect.Type,
 	value reflect.Value,
 	field reflect.StructField,
 	schema *openapi3.Schema,
+	bindingTag string,
 ) error {
-	fieldSchema := swagger.schemaFromType(value.Interface())
 	tags, err := structtag.Parse(string(field.Tag))
 	if err != nil {
 		return err
@@ -387,20 +690,89 @@ func (swagger *Swagger) schemaFromReflectStruct(
 		}
 		schema.Required = append(schema.Required, embedSchema.Required...)
 	}
-	tag, err := tags.Get(JSON)
+	tag, err := tags.Get(bindingTag)
 	if err != nil {
 		return err
 	}
-	parseTags(tag.Name, tags, schema, fieldSchema)
-	schema.Properties[tag.Name] = openapi3.NewSchemaRef("", fieldSchema)
+	if tag.Name == "-" && len(tag.Options) == 0 {
+		// encoding/json never writes the field
+		return nil
+	}
+	property := swagger.schemaRefFromType(value.Interface())
+	nullable := field.Type.Kind() == reflect.Ptr
+	path := fieldPath(owner, field)
+	if property.Ref == "" {
+		if validateTag, err := tags.Get(VALIDATE); err == nil {
+			if err := swagger.applyValidateRules(property, field.Type, validateRules(validateTag)); err != nil {
+				swagger.fieldError(path, err)
+			}
+		}
+		if err := parseAnnotationTags(tags, property.Value); err != nil {
+			swagger.fieldError(path, err)
+		}
+		property.Value.Nullable = property.Value.Nullable || nullable
+	} else if nullable || hasAnnotationTags(tags) {
+		// siblings of a $ref are ignored, the annotations go on a schema wrapping the reference
+		wrapper := openapi3.NewAllOfSchema(property.Value)
+		wrapper.AllOf[0] = property
+		if err := parseAnnotationTags(tags, wrapper); err != nil {
+			swagger.fieldError(path, err)
+		}
+		wrapper.Nullable = nullable
+		property = openapi3.NewSchemaRef("", wrapper)
+	}
+	name := tag.Name
+	if name == "" {
+		// like encoding/json, a tag with options only keeps the field name
+		name = field.Name
+	}
+	parseRequiredTag(name, tag, tags, schema, swagger.decoding)
+	schema.Properties[name] = property
 
 	return nil
 }
 
+// describeDecoded sets whether the next schemas describe values decoded by the api and returns the func
+// restoring the previous state
+func (swagger *Swagger) describeDecoded(decoding bool) func() {
+	previous := swagger.decoding
+	swagger.decoding = decoding
+
+	return func() { swagger.decoding = previous }
+}
+
 func (swagger *Swagger) sanitizePath(path string) string {
-	return fixPathRe.ReplaceAllString(path, "/{${1}}")
+	path, _ = parsePath(path)
+
+	return path
 }
 
+// MarshalJSON returns the json specification in the OpenAPIVersion format
 func (swagger *Swagger) MarshalJSON() ([]byte, error) {
-	return swagger.OpenAPI.MarshalJSON()
+	body, err := swagger.OpenAPI.MarshalJSON()
+	if err != nil || swagger.OpenAPIVersion != OpenAPI31 {
+		return body, err
+	}
+
+	return convertToOpenAPI31(body)
+}
+
+func (swagger *Swagger) MarshalYAML() ([]byte, error) {
+	body, err := swagger.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.JSONToYAML(body)
+}
+
+// WriteYAML writes the yaml specification to w, e.g. to generate an openapi.yaml file
+func (swagger *Swagger) WriteYAML(w io.Writer) error {
+	body, err := swagger.MarshalYAML()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+
+	return err
 }
@@ -0,0 +1,193 @@
+package swagger
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/invopop/yaml"
+	"github.com/pkg/errors"
+)
+
+// Swagger2Warning describes a construct of the openapi 3 document which swagger 2.0 can't express
+type Swagger2Warning struct {
+	Location string
+	Message  string
+}
+
+func (warning Swagger2Warning) String() string {
+	return warning.Location + ": " + warning.Message
+}
+
+// Swagger2 converts the openapi document to swagger 2.0 for the legacy tools,
+// the unsupported constructs are dropped and reported as warnings
+func (swagger *Swagger) Swagger2() (*openapi2.T, []Swagger2Warning, error) {
+	// the conversion mutates the document, it works on a copy
+	body, err := swagger.OpenAPI.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	doc3, err := openapi3.NewLoader().LoadFromData(body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, ErrSwagger2Conversion.Error())
+	}
+	warnings := downgradeToSwagger2(doc3)
+	doc2, err := openapi2conv.FromV3(doc3)
+	if err != nil {
+		return nil, warnings, errors.Wrap(err, ErrSwagger2Conversion.Error())
+	}
+
+	return doc2, warnings, nil
+}
+
+func (swagger *Swagger) MarshalSwagger2JSON() ([]byte, []Swagger2Warning, error) {
+	doc2, warnings, err := swagger.Swagger2()
+	if err != nil {
+		return nil, warnings, err
+	}
+	body, err := doc2.MarshalJSON()
+
+	return body, warnings, err
+}
+
+func (swagger *Swagger) MarshalSwagger2YAML() ([]byte, []Swagger2Warning, error) {
+	body, warnings, err := swagger.MarshalSwagger2JSON()
+	if err != nil {
+		return nil, warnings, err
+	}
+	body, err = yaml.JSONToYAML(body)
+
+	return body, warnings, err
+}
+
+// downgradeToSwagger2 removes from the document what openapi2conv can't convert
+func downgradeToSwagger2(doc3 *openapi3.T) []Swagger2Warning {
+	var warnings []Swagger2Warning
+	warn := func(location, format string, args ...interface{}) {
+		warnings = append(warnings, Swagger2Warning{Location: location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(doc3.Servers) > 1 {
+		warn("servers", "only the first server %s is kept as host and basePath", doc3.Servers[0].URL)
+	}
+	for i, server := range doc3.Servers {
+		if len(server.Variables) > 0 {
+			warn(fmt.Sprintf("servers/%d", i), "server variables are not supported")
+		}
+	}
+	if _, ok := doc3.Extensions[webhooksExtension]; ok {
+		warn("webhooks", "webhooks are not supported")
+		delete(doc3.Extensions, webhooksExtension)
+	}
+
+	oidcSchemes := map[string]bool{}
+	for _, name := range sortedKeys(doc3.Components.SecuritySchemes) {
+		scheme := doc3.Components.SecuritySchemes[name].Value
+		location := "components/securitySchemes/" + name
+		switch {
+		case scheme.Type == SecurityTypeOpenIDConnect:
+			// the id tokens are bearer tokens, the routes stay authenticated
+			warn(location, "openid connect is not supported, the scheme becomes an api key in the Authorization header")
+			oidcSchemes[name] = true
+			doc3.Components.SecuritySchemes[name].Value = openIDConnectAsBearer(scheme)
+		case scheme.Type == SecurityTypeHTTP && scheme.Scheme != "basic":
+			warn(location, "the %s http scheme becomes an api key in the Authorization header", scheme.Scheme)
+		case scheme.Type == SecurityTypeOAuth2 && countOAuthFlows(scheme.Flows) > 1:
+			warn(location, "only one oauth2 flow is kept")
+		}
+	}
+	doc3.Security = withoutScopes(doc3.Security, oidcSchemes, func(name string) {
+		warn("security", "the openid connect scheme %s is documented as an api key without scopes", name)
+	})
+
+	for _, path := range sortedKeys(doc3.Paths) {
+		operations := doc3.Paths[path].Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			location := method + " " + path
+			if operation.Security != nil {
+				security := withoutScopes(*operation.Security, oidcSchemes, func(name string) {
+					warn(location, "the openid connect scheme %s is documented as an api key without scopes", name)
+				})
+				operation.Security = &security
+			}
+			operation.Parameters = withoutCookieParameters(operation.Parameters, func(name string) {
+				warn(location, "the cookie parameter %s is not supported", name)
+			})
+			if operation.RequestBody != nil && len(operation.RequestBody.Value.Content) > 1 {
+				warn(location, "only one request body content type is kept")
+			}
+		}
+	}
+
+	return warnings
+}
+
+func withoutCookieParameters(parameters openapi3.Parameters, onRemove func(name string)) openapi3.Parameters {
+	kept := make(openapi3.Parameters, 0, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.Value != nil && parameter.Value.In == openapi3.ParameterInCookie {
+			onRemove(parameter.Value.Name)
+
+			continue
+		}
+		kept = append(kept, parameter)
+	}
+
+	return kept
+}
+
+// openIDConnectAsBearer describes an openid connect scheme as the bearer token it accepts,
+// openapi2conv turns it into an api key
+func openIDConnectAsBearer(scheme *openapi3.SecurityScheme) *openapi3.SecurityScheme {
+	description := "OpenID Connect, see " + scheme.OpenIdConnectUrl
+	if scheme.Description != "" {
+		description = scheme.Description + "\n\n" + description
+	}
+
+	return openapi3.NewJWTSecurityScheme().WithDescription(description)
+}
+
+// withoutScopes empties the scopes of the converted openid connect schemes, an api key has none
+func withoutScopes(
+	requirements openapi3.SecurityRequirements,
+	converted map[string]bool,
+	onConvert func(name string),
+) openapi3.SecurityRequirements {
+	for _, requirement := range requirements {
+		for _, name := range sortedKeys(requirement) {
+			if converted[name] {
+				onConvert(name)
+				requirement[name] = []string{}
+			}
+		}
+	}
+
+	return requirements
+}
+
+func countOAuthFlows(flows *openapi3.OAuthFlows) int {
+	if flows == nil {
+		return 0
+	}
+	count := 0
+	for _, flow := range []*openapi3.OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+		if flow != nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
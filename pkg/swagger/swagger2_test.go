@@ -0,0 +1,106 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger_Swagger2(t *testing.T) {
+	type PetModel struct {
+		ID      int    `uri:"id"`
+		Session string `cookie:"session"`
+		Name    string `json:"name"`
+	}
+	type Pet struct {
+		Name string `json:"name"`
+	}
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/pets/:id", http.MethodPut, nil,
+			router.Model(new(PetModel)),
+			router.Security("oidc"),
+			router.Security("bearer"),
+			router.Responses(router.ResponseMap{"200": {Description: "ok", Model: Pet{}}}),
+		),
+	},
+		WithSecurityScheme("bearer", JWTSecurityScheme()),
+		WithSecurityScheme("oidc", OpenIDConnectSecurityScheme("https://example.com/.well-known/openid-configuration")),
+	)
+	require.NoError(t, err)
+	swag.OpenAPI.Servers = openapi3.Servers{
+		{URL: "https://api.example.com/v1"},
+		{URL: "https://staging.example.com/v1"},
+	}
+
+	t.Run("Should convert the document and report the unsupported constructs", func(t *testing.T) {
+		doc2, warnings, err := swag.Swagger2()
+		require.NoError(t, err)
+
+		assert.Equal(t, "2.0", doc2.Swagger)
+		assert.Equal(t, "api.example.com", doc2.Host)
+		assert.Equal(t, "/v1", doc2.BasePath)
+		assert.Contains(t, doc2.Definitions, "Pet")
+		require.Contains(t, doc2.SecurityDefinitions, "oidc")
+		oidc := doc2.SecurityDefinitions["oidc"]
+		assert.Equal(t, "apiKey", oidc.Type)
+		assert.Equal(t, "header", oidc.In)
+		assert.Equal(t, "Authorization", oidc.Name)
+		assert.Contains(t, oidc.Description, "https://example.com/.well-known/openid-configuration")
+		operation := doc2.Paths["/pets/{id}"].Put
+		require.Len(t, operation.Parameters, 2)
+		for _, parameter := range operation.Parameters {
+			assert.NotEqual(t, "cookie", parameter.In)
+		}
+		require.Len(t, *operation.Security, 2)
+		assert.Contains(t, (*operation.Security)[0], "oidc")
+
+		assert.Equal(t, []Swagger2Warning{
+			{Location: "servers", Message: "only the first server https://api.example.com/v1 is kept as host and basePath"},
+			{Location: "components/securitySchemes/bearer", Message: "the bearer http scheme becomes an api key in the Authorization header"},
+			{Location: "components/securitySchemes/oidc", Message: "openid connect is not supported, the scheme becomes an api key in the Authorization header"},
+			{Location: "PUT /pets/{id}", Message: "the openid connect scheme oidc is documented as an api key without scopes"},
+			{Location: "PUT /pets/{id}", Message: "the cookie parameter session is not supported"},
+		}, warnings)
+	})
+
+	t.Run("Should keep a route secured by openid connect only authenticated", func(t *testing.T) {
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/me", http.MethodGet, nil, router.Security("oidc", "profile")),
+		}, WithSecurityScheme("oidc", OpenIDConnectSecurityScheme("https://example.com/.well-known/openid-configuration")))
+		require.NoError(t, err)
+
+		doc2, warnings, err := swag.Swagger2()
+		require.NoError(t, err)
+		security := doc2.Paths["/me"].Get.Security
+		require.NotNil(t, security)
+		assert.Equal(t, []map[string][]string{{"oidc": {}}}, []map[string][]string(*security))
+		assert.Contains(t, warnings, Swagger2Warning{
+			Location: "GET /me",
+			Message:  "the openid connect scheme oidc is documented as an api key without scopes",
+		})
+	})
+
+	t.Run("Should not alter the openapi document", func(t *testing.T) {
+		_, _, err := swag.Swagger2()
+		require.NoError(t, err)
+
+		assert.Equal(t, SecurityTypeOpenIDConnect, swag.OpenAPI.Components.SecuritySchemes["oidc"].Value.Type)
+		assert.Len(t, swag.OpenAPI.Paths["/pets/{id}"].Put.Parameters, 2)
+	})
+
+	t.Run("Should marshal the swagger 2.0 document", func(t *testing.T) {
+		body, warnings, err := swag.MarshalSwagger2JSON()
+		require.NoError(t, err)
+		assert.NotEmpty(t, warnings)
+		assert.Contains(t, string(body), `"swagger":"2.0"`)
+
+		body, _, err = swag.MarshalSwagger2YAML()
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `swagger: "2.0"`)
+	})
+}
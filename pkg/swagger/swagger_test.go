@@ -2,12 +2,17 @@
 package swagger
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -253,8 +258,8 @@ func TestSwagger_schemaFromModel(t *testing.T) {
 		swag := &Swagger{}
 
 		type FakeModel struct {
-			Name   string `json:"name" description:"desc of the name" example:"John Doe"`
-			Age    uint   `json:"age" default:"21"`
+			Name   string `json:"name,omitempty" description:"desc of the name" example:"John Doe"`
+			Age    uint   `json:"age,omitempty" default:"21"`
 			Active bool   `json:"active" validate:"required"`
 		}
 
@@ -266,11 +271,42 @@ func TestSwagger_schemaFromModel(t *testing.T) {
 		require.Equal(t, "desc of the name", schema.Properties["name"].Value.Description)
 		require.Equal(t, "John Doe", schema.Properties["name"].Value.Example)
 		require.Equal(t, openapi3.TypeInteger, schema.Properties["age"].Value.Type)
-		require.Equal(t, "21", schema.Properties["age"].Value.Default)
+		require.Equal(t, int64(21), schema.Properties["age"].Value.Default)
 		require.Equal(t, openapi3.TypeBoolean, schema.Properties["active"].Value.Type)
 		require.Equal(t, []string{"active"}, schema.Required)
 	})
 
+	t.Run("Should follow the encoding/json semantics", func(t *testing.T) {
+		swag := &Swagger{}
+
+		type FakeModel struct {
+			Name     string   `json:"name"`
+			Nickname *string  `json:"nickname"`
+			Address  *Address `json:"address,omitempty" description:"home address"`
+			Age      *int     `json:"age,omitempty" validate:"required"`
+			Password string   `json:"-"`
+			Dash     string   `json:"-,"`
+			Fallback string   `json:",omitempty"`
+		}
+
+		schema := swag.schemaFromModel(new(FakeModel))
+
+		require.Len(t, schema.Properties, 6)
+		assert.NotContains(t, schema.Properties, "Password")
+		assert.NotContains(t, schema.Properties, "")
+		assert.Contains(t, schema.Properties, "Fallback")
+		assert.Contains(t, schema.Properties, "-")
+		assert.Equal(t, []string{"name", "nickname", "age", "-"}, schema.Required)
+		assert.False(t, schema.Properties["name"].Value.Nullable)
+		assert.Equal(t, openapi3.TypeString, schema.Properties["nickname"].Value.Type)
+		assert.True(t, schema.Properties["nickname"].Value.Nullable)
+		assert.True(t, schema.Properties["age"].Value.Nullable)
+		address := schema.Properties["address"].Value
+		assert.True(t, address.Nullable)
+		assert.Equal(t, "home address", address.Description)
+		assert.Equal(t, "#/components/schemas/Address", address.AllOf[0].Ref)
+	})
+
 	t.Run("Should create a schema from slice", func(t *testing.T) {
 		swag := &Swagger{}
 
@@ -287,6 +323,57 @@ func TestSwagger_schemaFromModel(t *testing.T) {
 	})
 }
 
+func TestSwagger_requiredByDirection(t *testing.T) {
+	type Counter struct {
+		Name    string  `json:"name" validate:"required"`
+		Count   int     `json:"count"`
+		Address Address `json:"address"`
+	}
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/counters", http.MethodPost, nil, router.Model(Counter{}), router.Responses(router.ResponseMap{
+			"200": {Description: "OK", Model: Counter{}},
+		})),
+	})
+	require.NoError(t, err)
+	operation := swag.OpenAPI.Paths["/counters"].Post
+
+	t.Run("Should only require the validated fields of a request body", func(t *testing.T) {
+		schema := operation.RequestBody.Value.Content.Get("application/json").Schema.Value
+
+		assert.Equal(t, []string{"name"}, schema.Required)
+		assert.Equal(t, "#/components/schemas/AddressInput", schema.Properties["address"].Ref)
+		assert.Empty(t, swag.OpenAPI.Components.Schemas["AddressInput"].Value.Required)
+	})
+
+	t.Run("Should require the fields always encoded in a response", func(t *testing.T) {
+		ref := operation.Responses.Get(http.StatusOK).Value.Content.Get("application/json").Schema
+		require.Equal(t, "#/components/schemas/Counter", ref.Ref)
+
+		assert.Equal(t, []string{"name", "count", "address"}, ref.Value.Required)
+		assert.Equal(t, "#/components/schemas/Address", ref.Value.Properties["address"].Ref)
+		assert.Equal(t, []string{"city"}, swag.OpenAPI.Components.Schemas["Address"].Value.Required)
+	})
+
+	t.Run("Should share the components whose required fields don't change", func(t *testing.T) {
+		type Tag struct {
+			Label string `json:"label,omitempty"`
+		}
+		type Post struct {
+			Tags []Tag `json:"tags,omitempty"`
+		}
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/posts", http.MethodPost, nil, router.Model(Post{}), router.Responses(router.ResponseMap{
+				"200": {Description: "OK", Model: Post{}},
+			})),
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, swag.OpenAPI.Components.Schemas, "Tag")
+		assert.NotContains(t, swag.OpenAPI.Components.Schemas, "TagInput")
+		assert.NotContains(t, swag.OpenAPI.Components.Schemas, "PostInput")
+	})
+}
+
 func TestSwagger_sanitizePath(t *testing.T) {
 	tests := []struct {
 		input string
@@ -308,6 +395,26 @@ func TestSwagger_sanitizePath(t *testing.T) {
 			input: "/:welcome/fr/:name",
 			want:  "/{welcome}/fr/{name}",
 		},
+		{
+			input: "/files/*filepath",
+			want:  "/files/{filepath}",
+		},
+		{
+			input: "/users/{id}/posts/{slug:[a-z-]+}",
+			want:  "/users/{id}/posts/{slug}",
+		},
+		{
+			input: "/codes/{code:[A-Z]{3}}",
+			want:  "/codes/{code}",
+		},
+		{
+			input: "/files/{path...}",
+			want:  "/files/{path}",
+		},
+		{
+			input: "/users/{$}",
+			want:  "/users/",
+		},
 	}
 	swag := &Swagger{}
 	for _, tt := range tests {
@@ -361,3 +468,210 @@ func TestSwagger_validateSchema(t *testing.T) {
 		assert.Equal(t, []any{"red", "green", "blue"}, schema.Value.Enum)
 	})
 }
+
+func TestSwagger_requestBody(t *testing.T) {
+	type FakeModel struct {
+		ID     int    `uri:"id"`
+		Token  string `header:"Authorization"`
+		Name   string `json:"name" validate:"required"`
+		Age    uint   `json:"age,omitempty"`
+		Secret string
+	}
+
+	t.Run("Should build the request body from the json fields", func(t *testing.T) {
+		swag := &Swagger{}
+
+		body, err := swag.requestBody(router.New("/users/:id", http.MethodPost, nil, router.Model(new(FakeModel))), true)
+		require.NoError(t, err)
+		require.NotNil(t, body)
+		require.True(t, body.Value.Required)
+		mediaType := body.Value.Content.Get("application/json")
+		require.NotNil(t, mediaType)
+		schema := mediaType.Schema.Value
+		require.Len(t, schema.Properties, 2)
+		assert.Equal(t, openapi3.TypeString, schema.Properties["name"].Value.Type)
+		assert.Equal(t, openapi3.TypeInteger, schema.Properties["age"].Value.Type)
+		assert.Equal(t, []string{"name"}, schema.Required)
+	})
+
+	t.Run("Should use the request content type", func(t *testing.T) {
+		swag := &Swagger{}
+		route := router.New("/users/:id", http.MethodPut, nil, router.Model(new(FakeModel)))
+		route.RequestContentType = "application/vnd.api+json"
+
+		body, err := swag.requestBody(route, true)
+		require.NoError(t, err)
+		require.NotNil(t, body.Value.Content.Get("application/vnd.api+json"))
+	})
+
+	t.Run("Should build a multipart request body from the form fields", func(t *testing.T) {
+		type UploadModel struct {
+			ID          int                     `uri:"id"`
+			Title       string                  `form:"title" validate:"required"`
+			Tags        []string                `form:"tags"`
+			File        *multipart.FileHeader   `form:"file"`
+			Attachments []*multipart.FileHeader `form:"attachments"`
+			Home        Address                 `form:"home"`
+			Contacts    []Address               `form:"contacts"`
+			Ignored     string                  `json:"ignored"`
+		}
+		swag := &Swagger{}
+		route := router.New("/documents/:id", http.MethodPost, nil, router.Model(new(UploadModel)))
+		route.RequestContentType = router.MIMEMultipartForm
+
+		body, err := swag.requestBody(route, true)
+		require.NoError(t, err)
+		mediaType := body.Value.Content.Get(router.MIMEMultipartForm)
+		require.NotNil(t, mediaType)
+		schema := mediaType.Schema.Value
+		require.Len(t, schema.Properties, 6)
+		assert.Equal(t, []string{"title"}, schema.Required)
+		assert.Equal(t, "binary", schema.Properties["file"].Value.Format)
+		assert.Equal(t, "binary", schema.Properties["attachments"].Value.Items.Value.Format)
+		assert.Equal(t, router.MIMETextPlain, mediaType.Encoding["title"].ContentType)
+		assert.Equal(t, router.MIMETextPlain, mediaType.Encoding["tags"].ContentType)
+		assert.Equal(t, router.MIMEOctetStream, mediaType.Encoding["file"].ContentType)
+		assert.Equal(t, router.MIMEOctetStream, mediaType.Encoding["attachments"].ContentType)
+		assert.Equal(t, router.MIMEApplicationJSON, mediaType.Encoding["home"].ContentType)
+		assert.Equal(t, router.MIMEApplicationJSON, mediaType.Encoding["contacts"].ContentType)
+	})
+
+	t.Run("Should build an urlencoded request body from the form fields", func(t *testing.T) {
+		type LoginModel struct {
+			Login    string `form:"login"`
+			Password string `form:"password"`
+		}
+		swag := &Swagger{}
+		route := router.New("/login", http.MethodPost, nil, router.Model(new(LoginModel)))
+		route.RequestContentType = router.MIMEApplicationForm
+
+		body, err := swag.requestBody(route, true)
+		require.NoError(t, err)
+		mediaType := body.Value.Content.Get(router.MIMEApplicationForm)
+		require.NotNil(t, mediaType)
+		require.Len(t, mediaType.Schema.Value.Properties, 2)
+	})
+
+	t.Run("Should not build a request body for a read operation", func(t *testing.T) {
+		swag := &Swagger{}
+
+		body, err := swag.requestBody(router.New("/users/:id", http.MethodGet, nil, router.Model(new(FakeModel))), true)
+		require.NoError(t, err)
+		require.Nil(t, body)
+	})
+
+	t.Run("Should keep the other fields as parameters", func(t *testing.T) {
+		swag := &Swagger{
+			Routers: []*router.Router{
+				router.New("/users/:id", http.MethodPatch, nil, router.Model(new(FakeModel))),
+			},
+		}
+
+		paths, err := swag.paths()
+		require.NoError(t, err)
+		operation := paths["/users/{id}"].Patch
+		require.Len(t, operation.Parameters, 2)
+		require.NotNil(t, operation.RequestBody)
+	})
+
+	t.Run("Should describe a list body without parameters", func(t *testing.T) {
+		typed := router.JSON("/users", http.MethodPost, func(_ context.Context, users []User) (string, error) {
+			return "", nil
+		})
+
+		swag, err := New("foo", "bar", "1.0.0", []*router.Router{typed})
+		require.NoError(t, err)
+		operation := swag.OpenAPI.Paths["/users"].Post
+		assert.Empty(t, operation.Parameters)
+		schema := operation.RequestBody.Value.Content.Get("application/json").Schema.Value
+		assert.Equal(t, openapi3.TypeArray, schema.Type)
+		assert.Equal(t, "#/components/schemas/UserInput", schema.Items.Ref)
+	})
+}
+
+func TestSwagger_WriteYAML(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/", http.MethodGet, func() {}),
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, swag.WriteYAML(&buf))
+
+	assert.Contains(t, buf.String(), "openapi: 3.0.0")
+	assert.Contains(t, buf.String(), "title: foo")
+}
+
+func TestSwagger_annotationValues(t *testing.T) {
+	type Filter struct {
+		Limit int `query:"limit" default:"20" example:"50"`
+	}
+	type Settings struct {
+		Retries  int               `json:"retries" default:"3"`
+		Ratio    float64           `json:"ratio" default:"0.5" example:"0.75"`
+		Enabled  bool              `json:"enabled" default:"true"`
+		Since    time.Time         `json:"since" example:"2024-01-02T15:04:05Z"`
+		Tags     []string          `json:"tags" default:"a,b" example:"[\"x\"]"`
+		Ports    []int             `json:"ports" example:"80,443"`
+		Labels   map[string]string `json:"labels" example:"{\"env\":\"prod\"}"`
+		Address  Address           `json:"address" example:"{\"city\":\"Paris\"}"`
+		Nickname string            `json:"nickname" default:"Jo, the best"`
+	}
+
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/settings", http.MethodGet, nil, router.Model(Filter{}), router.Responses(router.ResponseMap{
+			"200": {Description: "OK", Model: Settings{}},
+		})),
+	})
+	require.NoError(t, err)
+
+	t.Run("Should parse the values to the field type", func(t *testing.T) {
+		schema := swag.OpenAPI.Paths["/settings"].Get.Responses.Get(http.StatusOK).Value.Content.Get("application/json").Schema.Value
+		properties := schema.Properties
+
+		assert.Equal(t, int64(3), properties["retries"].Value.Default)
+		assert.Equal(t, 0.5, properties["ratio"].Value.Default)
+		assert.Equal(t, 0.75, properties["ratio"].Value.Example)
+		assert.Equal(t, true, properties["enabled"].Value.Default)
+		assert.Equal(t, "2024-01-02T15:04:05Z", properties["since"].Value.Example)
+		assert.Equal(t, []interface{}{"a", "b"}, properties["tags"].Value.Default)
+		assert.Equal(t, []interface{}{"x"}, properties["tags"].Value.Example)
+		assert.Equal(t, []interface{}{int64(80), int64(443)}, properties["ports"].Value.Example)
+		assert.Equal(t, map[string]interface{}{"env": "prod"}, properties["labels"].Value.Example)
+		assert.Equal(t, map[string]interface{}{"city": "Paris"}, properties["address"].Value.Example)
+		assert.Equal(t, "Jo, the best", properties["nickname"].Value.Default)
+		require.NoError(t, schema.Validate(context.Background()))
+	})
+
+	t.Run("Should parse the values of the parameters", func(t *testing.T) {
+		limit := swag.OpenAPI.Paths["/settings"].Get.Parameters.GetByInAndName("query", "limit")
+		require.NotNil(t, limit)
+		assert.Equal(t, int64(20), limit.Schema.Value.Default)
+		assert.Equal(t, int64(50), limit.Schema.Value.Example)
+	})
+
+	t.Run("Should name the field of an invalid value", func(t *testing.T) {
+		type Invalid struct {
+			Retries int `json:"retries" default:"three"`
+		}
+		_, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/settings", http.MethodPost, nil, router.Model(Invalid{})),
+		})
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrParseAnnotationTag))
+		assert.Contains(t, err.Error(), `swagger.Invalid.Retries: default:"three"`)
+	})
+
+	t.Run("Should check the dates", func(t *testing.T) {
+		type Invalid struct {
+			Since time.Time `query:"since" example:"yesterday"`
+		}
+		_, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/settings", http.MethodGet, nil, router.Model(Invalid{})),
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid.Since")
+	})
+}
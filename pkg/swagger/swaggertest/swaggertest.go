@@ -0,0 +1,26 @@
+// Package swaggertest checks that an adapter serves the documentation endpoints of a swagger
+package swaggertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/swagger"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertDocs requests each url of DocsURLs and an embedded asset on the handler. The mount point prefixes
+// the urls when the documentation is registered on a group, e.g. /api.
+func AssertDocs(t *testing.T, handler http.Handler, swag *swagger.Swagger, mountPoint string) {
+	t.Helper()
+	urls := swag.DocsURLs()
+	if prefix, ok := swag.AssetsPrefix(); ok {
+		urls = append(urls, prefix+"/swagger-ui/swagger-ui.css")
+	}
+	for _, url := range urls {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mountPoint+url, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, "GET %s", mountPoint+url)
+	}
+}
@@ -1,30 +1,94 @@
 package swagger
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/fatih/structtag"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/pkg/errors"
 )
 
 // validate option
 const (
-	MinOption  = "min="
-	MaxOption  = "max="
-	LenOption  = "len="
-	EnumOption = "enum="
+	MinOption        = "min="
+	MaxOption        = "max="
+	LenOption        = "len="
+	EnumOption       = "enum="
+	GtOption         = "gt="
+	GteOption        = "gte="
+	LtOption         = "lt="
+	LteOption        = "lte="
+	OneOfOption      = "oneof="
+	EqOption         = "eq="
+	NeOption         = "ne="
+	StartsWithOption = "startswith="
+	EndsWithOption   = "endswith="
+	ContainsOption   = "contains="
+	DatetimeOption   = "datetime="
+	UniqueOption     = "unique"
+	DiveOption       = "dive"
+	KeysOption       = "keys"
+	EndKeysOption    = "endkeys"
 )
 
-type validateOption = func(*openapi3.SchemaRef, string) error
+var oneOfValueRe = regexp.MustCompile(`'[^']*'|\S+`)
+
+// formatOptions are the validator tags matching a json schema format
+var formatOptions = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"ipv4":             "ipv4",
+	"ip4_addr":         "ipv4",
+	"ipv6":             "ipv6",
+	"ip6_addr":         "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+}
+
+// patternOptions are the validator tags described by a regex
+var patternOptions = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"hexcolor":    `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+	"ascii":       `^[\x00-\x7F]*$`,
+	"lowercase":   `^[^A-Z]*$`,
+	"uppercase":   `^[^a-z]*$`,
+	"jwt":         `^[A-Za-z0-9-_]+\.[A-Za-z0-9-_]+\.[A-Za-z0-9-_]*$`,
+}
+
+// datetimeFormats are the layouts of the datetime tag matching a json schema format
+var datetimeFormats = map[string]string{
+	"2006-01-02":           "date",
+	time.RFC3339:           "date-time",
+	time.RFC3339Nano:       "date-time",
+	"2006-01-02T15:04:05Z": "date-time",
+}
+
+type builtinValidateOption = func(*openapi3.SchemaRef, string) error
 
 func validateLenOption(schema *openapi3.SchemaRef, option string) error {
 	if strings.HasPrefix(option, LenOption) {
-		value, err := strconv.ParseInt(option[len(LenOption):], BASEINT, BITSIZE)
+		value, err := strconv.ParseFloat(option[len(LenOption):], BITSIZE)
 		if err != nil {
 			return errors.Wrap(err, ErrParseLenOption.Error())
 		}
-		schema.Value.WithLength(value)
+		withBound(schema.Value, value, true, false)
+		withBound(schema.Value, value, false, false)
 	}
 
 	return nil
@@ -36,7 +100,7 @@ func validateMaxOption(schema *openapi3.SchemaRef, option string) error {
 		if err != nil {
 			return errors.Wrap(err, ErrParseMaxOption.Error())
 		}
-		schema.Value.WithMax(value)
+		withBound(schema.Value, value, false, false)
 	}
 
 	return nil
@@ -48,21 +112,238 @@ func validateMinOption(schema *openapi3.SchemaRef, option string) error {
 		if err != nil {
 			return errors.Wrap(err, ErrParseMinOption.Error())
 		}
-		schema.Value.WithMin(value)
+		withBound(schema.Value, value, true, false)
 	}
 
 	return nil
 }
 
+// withBound sets a lower or an upper bound on the keyword matching the schema type: the length
+// of a string, the items of an array, the properties of a map or the value of a number
+func withBound(schema *openapi3.Schema, value float64, lower, exclusive bool) {
+	switch schema.Type {
+	case openapi3.TypeString, openapi3.TypeArray, openapi3.TypeObject:
+		count := int64(value)
+		if exclusive && lower {
+			count++
+		} else if exclusive {
+			count--
+		}
+		if count < 0 {
+			count = 0
+		}
+		withCount(schema, count, lower)
+	default:
+		if lower {
+			schema.WithMin(value).WithExclusiveMin(exclusive)
+		} else {
+			schema.WithMax(value).WithExclusiveMax(exclusive)
+		}
+	}
+}
+
+func withCount(schema *openapi3.Schema, count int64, lower bool) {
+	switch {
+	case schema.Type == openapi3.TypeString && lower:
+		schema.WithMinLength(count)
+	case schema.Type == openapi3.TypeString:
+		schema.WithMaxLength(count)
+	case schema.Type == openapi3.TypeArray && lower:
+		schema.WithMinItems(count)
+	case schema.Type == openapi3.TypeArray:
+		schema.WithMaxItems(count)
+	case lower:
+		schema.WithMinProperties(count)
+	default:
+		schema.WithMaxProperties(count)
+	}
+}
+
 func validateEnumOption(schema *openapi3.SchemaRef, option string) error {
 	if strings.HasPrefix(option, EnumOption) {
 		optionItems := strings.Split(option[len(EnumOption):], ",")
 		enums := make([]interface{}, len(optionItems))
 		for i, optionItem := range optionItems {
-			enums[i] = optionItem
+			value, err := enumValue(schema.Value, optionItem)
+			if err != nil {
+				return errors.Wrap(err, ErrParseEnumOption.Error())
+			}
+			enums[i] = value
 		}
 		schema.Value.WithEnum(enums...)
 	}
 
 	return nil
 }
+
+// validateComparisonOption maps gt, gte, lt and lte to the bounds matching the schema type
+func validateComparisonOption(schema *openapi3.SchemaRef, option string) error {
+	for _, prefix := range []string{GtOption, GteOption, LtOption, LteOption} {
+		if !strings.HasPrefix(option, prefix) {
+			continue
+		}
+		value, err := strconv.ParseFloat(option[len(prefix):], BITSIZE)
+		if err != nil {
+			return errors.Wrap(ErrParseValidateOption, option)
+		}
+		lower := prefix == GtOption || prefix == GteOption
+		exclusive := prefix == GtOption || prefix == LtOption
+		withBound(schema.Value, value, lower, exclusive)
+	}
+
+	return nil
+}
+
+// validateOneOfOption maps oneof, whose values are separated by spaces and may be quoted, to an enum
+func validateOneOfOption(schema *openapi3.SchemaRef, option string) error {
+	if !strings.HasPrefix(option, OneOfOption) {
+		return nil
+	}
+	var enums []interface{}
+	for _, item := range oneOfValues(option[len(OneOfOption):]) {
+		value, err := enumValue(schema.Value, item)
+		if err != nil {
+			return errors.Wrap(ErrParseValidateOption, option)
+		}
+		enums = append(enums, value)
+	}
+	schema.Value.WithEnum(enums...)
+
+	return nil
+}
+
+func oneOfValues(values string) []string {
+	items := oneOfValueRe.FindAllString(values, -1)
+	for i, item := range items {
+		items[i] = strings.Trim(item, "'")
+	}
+
+	return items
+}
+
+// enumValue converts a value of the tag to the type of the schema
+func enumValue(schema *openapi3.Schema, value string) (interface{}, error) {
+	switch schema.Type {
+	case openapi3.TypeInteger:
+		return strconv.ParseInt(value, BASEINT, BITSIZE)
+	case openapi3.TypeNumber:
+		return strconv.ParseFloat(value, BITSIZE)
+	case openapi3.TypeBoolean:
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
+
+// validateEqualOption maps eq to a single value enum and ne to its negation
+func validateEqualOption(schema *openapi3.SchemaRef, option string) error {
+	for _, prefix := range []string{EqOption, NeOption} {
+		if !strings.HasPrefix(option, prefix) {
+			continue
+		}
+		value, err := enumValue(schema.Value, option[len(prefix):])
+		if err != nil {
+			return errors.Wrap(ErrParseValidateOption, option)
+		}
+		if prefix == EqOption {
+			schema.Value.WithEnum(value)
+		} else {
+			schema.Value.Not = openapi3.NewSchemaRef("", openapi3.NewSchema().WithEnum(value))
+		}
+	}
+
+	return nil
+}
+
+func validateFormatOption(schema *openapi3.SchemaRef, option string) error {
+	if format, ok := formatOptions[option]; ok {
+		schema.Value.WithFormat(format)
+	}
+	if strings.HasPrefix(option, DatetimeOption) {
+		if format, ok := datetimeFormats[option[len(DatetimeOption):]]; ok {
+			schema.Value.WithFormat(format)
+		}
+	}
+
+	return nil
+}
+
+func validatePatternOption(schema *openapi3.SchemaRef, option string) error {
+	if pattern, ok := patternOptions[option]; ok {
+		withPattern(schema.Value, pattern)
+	}
+	switch {
+	case strings.HasPrefix(option, StartsWithOption):
+		withPattern(schema.Value, "^"+regexp.QuoteMeta(option[len(StartsWithOption):]))
+	case strings.HasPrefix(option, EndsWithOption):
+		withPattern(schema.Value, regexp.QuoteMeta(option[len(EndsWithOption):])+"$")
+	case strings.HasPrefix(option, ContainsOption):
+		withPattern(schema.Value, regexp.QuoteMeta(option[len(ContainsOption):]))
+	}
+
+	return nil
+}
+
+// withPattern sets the pattern of the schema, a schema has a single pattern so the next ones go in allOf
+func withPattern(schema *openapi3.Schema, pattern string) {
+	if schema.Pattern == "" {
+		schema.WithPattern(pattern)
+
+		return
+	}
+	schema.AllOf = append(schema.AllOf, openapi3.NewSchemaRef("", openapi3.NewSchema().WithPattern(pattern)))
+}
+
+func validateUniqueOption(schema *openapi3.SchemaRef, option string) error {
+	if option == UniqueOption && schema.Value.Type == openapi3.TypeArray {
+		schema.Value.UniqueItems = true
+	}
+
+	return nil
+}
+
+// validateRules splits a validate tag into its rules. The values of the legacy enum option are
+// separated by commas like the rules, they are gathered back.
+func validateRules(tag *structtag.Tag) []string {
+	var rules []string
+	for _, rule := range strings.Split(tag.Value(), ",") {
+		last := len(rules) - 1
+		if last >= 0 && strings.HasPrefix(rules[last], EnumOption) && !strings.Contains(rule, "=") && !isRuleName(rule) {
+			rules[last] += "," + rule
+
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func isRuleName(rule string) bool {
+	_, isFormat := formatOptions[rule]
+	_, isPattern := patternOptions[rule]
+
+	return isFormat || isPattern ||
+		rule == REQUIRED || rule == OMITEMPTY || rule == UniqueOption || rule == DiveOption || rule == KeysOption
+}
+
+// diveSchema returns the schema of the items of an array or of the values of a map, a reference
+// is wrapped so the rules don't alter the component
+func diveSchema(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	var items **openapi3.SchemaRef
+	switch {
+	case schema.Value.Type == openapi3.TypeArray && schema.Value.Items != nil:
+		items = &schema.Value.Items
+	case schema.Value.Type == openapi3.TypeObject && schema.Value.AdditionalProperties != nil:
+		items = &schema.Value.AdditionalProperties
+	default:
+		return nil
+	}
+	if (*items).Ref != "" {
+		wrapper := openapi3.NewAllOfSchema((*items).Value)
+		wrapper.AllOf[0] = *items
+		*items = openapi3.NewSchemaRef("", wrapper)
+	}
+
+	return *items
+}
@@ -0,0 +1,213 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/fatih/structtag"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{tag: `validate:"required,max=10"`, want: []string{"required", "max=10"}},
+		{tag: `validate:"min=1"`, want: []string{"min=1"}},
+		{tag: `validate:"enum=red,green,blue,required"`, want: []string{"enum=red,green,blue", "required"}},
+		{tag: `validate:"dive,oneof=a b"`, want: []string{"dive", "oneof=a b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			tags, err := structtag.Parse(tt.tag)
+			require.NoError(t, err)
+			tag, err := tags.Get(VALIDATE)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, validateRules(tag))
+		})
+	}
+}
+
+type Signup struct {
+	Email    string            `json:"email" validate:"required,email"`
+	Website  string            `json:"website" validate:"url"`
+	ID       string            `json:"id" validate:"uuid4"`
+	Addr     string            `json:"addr" validate:"ipv4"`
+	Host     string            `json:"host" validate:"hostname"`
+	Birthday string            `json:"birthday" validate:"datetime=2006-01-02"`
+	Nickname string            `json:"nickname" validate:"alphanum,startswith=x"`
+	Phone    string            `json:"phone" validate:"e164"`
+	Age      int               `json:"age" validate:"gt=17,lte=130"`
+	Score    float64           `json:"score" validate:"gte=0,lt=1"`
+	Plan     string            `json:"plan" validate:"oneof=free 'pro plus'"`
+	Level    int               `json:"level" validate:"oneof=1 2 3"`
+	Role     string            `json:"role" validate:"ne=admin"`
+	Tags     []string          `json:"tags" validate:"unique,dive,alpha"`
+	Friends  []Address         `json:"friends" validate:"dive,required"`
+	Labels   map[string]string `json:"labels" validate:"dive,keys,alpha,endkeys,email"`
+	Choice   string            `json:"choice" validate:"email|url"`
+}
+
+func TestHasRequiredRule(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{tag: `validate:"required"`, want: true},
+		{tag: `validate:"min=1,required"`, want: true},
+		{tag: `validate:"oneof=a b,required"`, want: true},
+		{tag: `validate:"omitempty,min=1"`, want: false},
+		{tag: `validate:"dive,required"`, want: false},
+		{tag: `json:"name"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			tags, err := structtag.Parse(tt.tag)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, hasRequiredRule(tags))
+		})
+	}
+}
+
+func TestSwagger_requiredRule(t *testing.T) {
+	type Search struct {
+		Page  int    `query:"page" validate:"min=1,required"`
+		Query string `json:"query,omitempty" validate:"max=50,required"`
+	}
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/search", http.MethodPost, nil, router.Model(Search{})),
+	})
+	require.NoError(t, err)
+	operation := swag.OpenAPI.Paths["/search"].Post
+
+	t.Run("Should require a parameter whatever the position of the rule", func(t *testing.T) {
+		page := operation.Parameters.GetByInAndName("query", "page")
+		require.NotNil(t, page)
+		assert.True(t, page.Required)
+		assert.Equal(t, 1.0, *page.Schema.Value.Min)
+	})
+
+	t.Run("Should require a property whatever the position of the rule", func(t *testing.T) {
+		schema := operation.RequestBody.Value.Content.Get("application/json").Schema.Value
+		assert.Equal(t, []string{"query"}, schema.Required)
+	})
+}
+
+func TestSwagger_validateVocabulary(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/signup", http.MethodPost, nil, router.Model(Signup{})),
+	})
+	require.NoError(t, err)
+	properties := swag.OpenAPI.Paths["/signup"].Post.RequestBody.Value.Content.Get("application/json").Schema.Value.Properties
+	property := func(name string) *openapi3.Schema {
+		require.Contains(t, properties, name)
+
+		return properties[name].Value
+	}
+
+	t.Run("Should map the validators to formats", func(t *testing.T) {
+		assert.Equal(t, "email", property("email").Format)
+		assert.Equal(t, "uri", property("website").Format)
+		assert.Equal(t, "uuid", property("id").Format)
+		assert.Equal(t, "ipv4", property("addr").Format)
+		assert.Equal(t, "hostname", property("host").Format)
+		assert.Equal(t, "date", property("birthday").Format)
+		assert.Empty(t, property("choice").Format)
+	})
+
+	t.Run("Should map the validators to patterns", func(t *testing.T) {
+		assert.Equal(t, `^[a-zA-Z0-9]+$`, property("nickname").Pattern)
+		require.Len(t, property("nickname").AllOf, 1)
+		assert.Equal(t, `^x`, property("nickname").AllOf[0].Value.Pattern)
+		assert.Equal(t, `^\+[1-9]?[0-9]{7,14}$`, property("phone").Pattern)
+	})
+
+	t.Run("Should map the comparisons to bounds", func(t *testing.T) {
+		age := property("age")
+		assert.Equal(t, 17.0, *age.Min)
+		assert.True(t, age.ExclusiveMin)
+		assert.Equal(t, 130.0, *age.Max)
+		assert.False(t, age.ExclusiveMax)
+		score := property("score")
+		assert.Equal(t, 0.0, *score.Min)
+		assert.False(t, score.ExclusiveMin)
+		assert.Equal(t, 1.0, *score.Max)
+		assert.True(t, score.ExclusiveMax)
+	})
+
+	t.Run("Should map oneof, eq and ne to enums", func(t *testing.T) {
+		assert.Equal(t, []interface{}{"free", "pro plus"}, property("plan").Enum)
+		assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, property("level").Enum)
+		require.NotNil(t, property("role").Not)
+		assert.Equal(t, []interface{}{"admin"}, property("role").Not.Value.Enum)
+	})
+
+	t.Run("Should apply the rules after dive to the items", func(t *testing.T) {
+		tags := property("tags")
+		assert.True(t, tags.UniqueItems)
+		assert.Equal(t, `^[a-zA-Z]+$`, tags.Items.Value.Pattern)
+		friends := property("friends")
+		assert.Equal(t, "#/components/schemas/AddressInput", friends.Items.Ref)
+		labels := property("labels")
+		assert.Equal(t, "email", labels.AdditionalProperties.Value.Format)
+		assert.Empty(t, labels.AdditionalProperties.Value.Pattern)
+	})
+
+	t.Run("Should produce a valid document", func(t *testing.T) {
+		require.NoError(t, swag.OpenAPI.Paths["/signup"].Post.RequestBody.Value.Validate(context.Background()))
+	})
+}
+
+func TestSwagger_validateBounds(t *testing.T) {
+	swag, err := New("foo", "bar", "1.0.0", nil)
+	require.NoError(t, err)
+	three, five := 3.0, 5.0
+	tests := []struct {
+		name  string
+		value interface{}
+		rules []string
+		want  *openapi3.Schema
+	}{
+		{name: "min on a string", value: "", rules: []string{"min=3"}, want: &openapi3.Schema{MinLength: 3}},
+		{name: "max on a string", value: "", rules: []string{"max=5"}, want: &openapi3.Schema{MaxLength: openapi3.Uint64Ptr(5)}},
+		{name: "len on a string", value: "", rules: []string{"len=3"}, want: &openapi3.Schema{MinLength: 3, MaxLength: openapi3.Uint64Ptr(3)}},
+		{name: "gt and lt on a string", value: "", rules: []string{"gt=2", "lt=6"}, want: &openapi3.Schema{MinLength: 3, MaxLength: openapi3.Uint64Ptr(5)}},
+		{name: "min on a slice", value: []int{}, rules: []string{"min=3"}, want: &openapi3.Schema{MinItems: 3}},
+		{name: "max on a slice", value: []int{}, rules: []string{"max=5"}, want: &openapi3.Schema{MaxItems: openapi3.Uint64Ptr(5)}},
+		{name: "len on a slice", value: []int{}, rules: []string{"len=3"}, want: &openapi3.Schema{MinItems: 3, MaxItems: openapi3.Uint64Ptr(3)}},
+		{name: "gte and lte on a slice", value: []int{}, rules: []string{"gte=3", "lte=5"}, want: &openapi3.Schema{MinItems: 3, MaxItems: openapi3.Uint64Ptr(5)}},
+		{name: "min on a map", value: map[string]int{}, rules: []string{"min=3"}, want: &openapi3.Schema{MinProps: 3}},
+		{name: "max on a map", value: map[string]int{}, rules: []string{"max=5"}, want: &openapi3.Schema{MaxProps: openapi3.Uint64Ptr(5)}},
+		{name: "len on a map", value: map[string]int{}, rules: []string{"len=3"}, want: &openapi3.Schema{MinProps: 3, MaxProps: openapi3.Uint64Ptr(3)}},
+		{name: "min and max on an integer", value: 0, rules: []string{"min=3", "max=5"}, want: &openapi3.Schema{Min: &three, Max: &five}},
+		{name: "len on an integer", value: int64(0), rules: []string{"len=5"}, want: &openapi3.Schema{Min: &five, Max: &five}},
+		{name: "gt on a number", value: 0.0, rules: []string{"gt=3"}, want: &openapi3.Schema{Min: &three, ExclusiveMin: true}},
+		{name: "lt on a number", value: 0.0, rules: []string{"lt=5"}, want: &openapi3.Schema{Max: &five, ExclusiveMax: true}},
+	}
+	for _, tt := range tests {
+		t.Run("Should apply "+tt.name, func(t *testing.T) {
+			schema, err := swag.validateSchema(tt.value, tt.rules)
+			require.NoError(t, err)
+			got := schema.Value
+
+			assert.Equal(t, tt.want.MinLength, got.MinLength)
+			assert.Equal(t, tt.want.MaxLength, got.MaxLength)
+			assert.Equal(t, tt.want.MinItems, got.MinItems)
+			assert.Equal(t, tt.want.MaxItems, got.MaxItems)
+			assert.Equal(t, tt.want.MinProps, got.MinProps)
+			assert.Equal(t, tt.want.MaxProps, got.MaxProps)
+			assert.Equal(t, tt.want.Min, got.Min)
+			assert.Equal(t, tt.want.Max, got.Max)
+			assert.Equal(t, tt.want.ExclusiveMin, got.ExclusiveMin)
+			assert.Equal(t, tt.want.ExclusiveMax, got.ExclusiveMax)
+		})
+	}
+}
@@ -1,10 +1,22 @@
 package swagger
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/fatih/structtag"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
 )
 
+// dateLayouts are the layouts of the string formats whose default and example values are checked
+var dateLayouts = map[string]string{
+	"date":      "2006-01-02",
+	"date-time": time.RFC3339,
+}
+
 func parseParameter(tags *structtag.Tags, parameter *openapi3.Parameter, tagName, in string) {
 	tag, err := tags.Get(tagName)
 	if err == nil {
@@ -36,21 +48,142 @@ func parseTagDescription(tags *structtag.Tags, parameter *openapi3.Parameter) {
 	}
 }
 
-func parseTags(tagName string, tags *structtag.Tags, schema, fieldSchema *openapi3.Schema) {
+// parseRequiredTag marks the field required when it's validated as such or, for the json the api encodes,
+// when encoding/json always writes it, i.e. without omitempty. encoding/json never requires a field
+// when decoding.
+func parseRequiredTag(name string, tag *structtag.Tag, tags *structtag.Tags, schema *openapi3.Schema, decoding bool) {
+	required := hasRequiredRule(tags)
+	if !decoding && alwaysEncoded(tag) {
+		required = true
+	}
+	if required {
+		schema.Required = append(schema.Required, name)
+	}
+}
+
+// hasRequiredRule reports whether a validate rule requires the field, wherever it is in the tag,
+// the rules following a dive require the items instead
+func hasRequiredRule(tags *structtag.Tags) bool {
 	validateTag, err := tags.Get(VALIDATE)
-	if err == nil && validateTag.Name == REQUIRED {
-		schema.Required = append(schema.Required, tagName)
+	if err != nil {
+		return false
+	}
+	for _, rule := range validateRules(validateTag) {
+		switch rule {
+		case DiveOption:
+			return false
+		case REQUIRED:
+			return true
+		}
+	}
+
+	return false
+}
+
+// alwaysEncoded reports whether encoding/json writes the field whatever its value
+func alwaysEncoded(tag *structtag.Tag) bool {
+	return tag.Key == JSON && !tag.HasOption(OMITEMPTY)
+}
+
+func hasAnnotationTags(tags *structtag.Tags) bool {
+	for _, key := range []string{DESCRIPTION, DEFAULT, EXAMPLE} {
+		if _, err := tags.Get(key); err == nil {
+			return true
+		}
 	}
+
+	return false
+}
+
+func parseAnnotationTags(tags *structtag.Tags, fieldSchema *openapi3.Schema) error {
 	descriptionTag, err := tags.Get(DESCRIPTION)
 	if err == nil {
 		fieldSchema.Description = descriptionTag.Name
 	}
+
+	return parseValueTags(tags, fieldSchema)
+}
+
+// parseValueTags sets the default and the example of the schema, they are parsed to the schema type
+func parseValueTags(tags *structtag.Tags, fieldSchema *openapi3.Schema) error {
 	defaultTag, err := tags.Get(DEFAULT)
 	if err == nil {
-		fieldSchema.Default = defaultTag.Name
+		value, err := annotationValue(fieldSchema, defaultTag.Value())
+		if err != nil {
+			return errors.Wrap(err, defaultTag.String())
+		}
+		fieldSchema.Default = value
 	}
 	exampleTag, err := tags.Get(EXAMPLE)
 	if err == nil {
-		fieldSchema.Example = exampleTag.Name
+		value, err := annotationValue(fieldSchema, exampleTag.Value())
+		if err != nil {
+			return errors.Wrap(err, exampleTag.String())
+		}
+		fieldSchema.Example = value
 	}
+
+	return nil
+}
+
+// annotationValue parses the value of a default or an example tag: numbers and booleans are parsed,
+// dates are checked, arrays are comma separated lists or json, and any other schema is json
+func annotationValue(schema *openapi3.Schema, raw string) (interface{}, error) {
+	switch schema.Type {
+	case openapi3.TypeString:
+		if layout, ok := dateLayouts[schema.Format]; ok {
+			if _, err := time.Parse(layout, raw); err != nil {
+				return nil, errors.Wrap(ErrParseAnnotationTag, err.Error())
+			}
+		}
+
+		return raw, nil
+	case openapi3.TypeInteger:
+		value, err := strconv.ParseInt(raw, BASEINT, BITSIZE)
+		if err != nil {
+			return nil, errors.Wrap(ErrParseAnnotationTag, err.Error())
+		}
+
+		return value, nil
+	case openapi3.TypeNumber:
+		value, err := strconv.ParseFloat(raw, BITSIZE)
+		if err != nil {
+			return nil, errors.Wrap(ErrParseAnnotationTag, err.Error())
+		}
+
+		return value, nil
+	case openapi3.TypeBoolean:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.Wrap(ErrParseAnnotationTag, err.Error())
+		}
+
+		return value, nil
+	case openapi3.TypeArray:
+		if !strings.HasPrefix(strings.TrimSpace(raw), "[") && schema.Items != nil && schema.Items.Value != nil {
+			return listValue(schema.Items.Value, raw)
+		}
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, errors.Wrap(ErrParseAnnotationTag, err.Error())
+	}
+
+	return value, nil
+}
+
+func listValue(itemSchema *openapi3.Schema, raw string) (interface{}, error) {
+	values := []interface{}{}
+	if raw == "" {
+		return values, nil
+	}
+	for _, item := range strings.Split(raw, ",") {
+		value, err := annotationValue(itemSchema, strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
 }
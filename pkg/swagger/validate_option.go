@@ -0,0 +1,58 @@
+package swagger
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// ValidateOption describes a custom validate tag in the schema of a field, e.g. validate:"iban" or
+// validate:"sku=EU". fieldType is the type of the field, or of its items after a dive, and param the text
+// following the equal sign. The returned error is reported with the path of the field.
+type ValidateOption func(fieldType reflect.Type, schema *openapi3.Schema, param string) error
+
+// RegisterValidateOption teaches the generator a validate tag, it replaces the built-in meaning of the tag if any.
+// The document is rebuilt so the routers using the tag get its schema.
+func (swagger *Swagger) RegisterValidateOption(name string, option ValidateOption) error {
+	swagger.registerValidateOption(name, option)
+
+	return swagger.buildOpenAPI()
+}
+
+func (swagger *Swagger) registerValidateOption(name string, option ValidateOption) {
+	if swagger.customOptions == nil {
+		swagger.customOptions = map[string]ValidateOption{}
+	}
+	swagger.customOptions[name] = option
+}
+
+// fieldError keeps the error of a field, the first one is returned once the document is built
+func (swagger *Swagger) fieldError(path string, err error) {
+	swagger.fieldErrors = append(swagger.fieldErrors, errors.Wrap(err, path))
+}
+
+// fieldPath locates a field in the error messages, e.g. billing.Invoice.Total
+func fieldPath(owner reflect.Type, field reflect.StructField) string {
+	if owner.Name() == "" {
+		return field.Name
+	}
+
+	return owner.String() + "." + field.Name
+}
+
+// elemType returns the type of the items of an array or of the values of a map
+func elemType(fieldType reflect.Type) reflect.Type {
+	if fieldType == nil {
+		return nil
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() { //nolint:exhaustive,nolintlint
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return fieldType.Elem()
+	default:
+		return fieldType
+	}
+}
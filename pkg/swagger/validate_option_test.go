@@ -0,0 +1,107 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnknownRegion = errors.New("unknown region")
+
+type Product struct {
+	SKU     string   `json:"sku" validate:"sku=EU"`
+	Aliases []string `json:"aliases" validate:"dive,sku"`
+	Account string   `json:"account" validate:"iban"`
+}
+
+type ProductQuery struct {
+	Tenant string `query:"tenant" validate:"required,tenant_id"`
+}
+
+func skuOption(fieldType reflect.Type, schema *openapi3.Schema, param string) error {
+	if fieldType.Kind() != reflect.String {
+		return errors.Errorf("sku expects a string, got %s", fieldType)
+	}
+	switch param {
+	case "":
+		schema.WithPattern(`^[A-Z]{2}-[0-9]{6}$`)
+	case "EU", "US":
+		schema.WithPattern(`^` + param + `-[0-9]{6}$`)
+	default:
+		return errUnknownRegion
+	}
+
+	return nil
+}
+
+func TestSwagger_RegisterValidateOption(t *testing.T) {
+	routers := []*router.Router{
+		router.New("/products", http.MethodPost, nil, router.Model(Product{})),
+	}
+
+	t.Run("Should describe the custom tags", func(t *testing.T) {
+		var fieldTypes []reflect.Type
+		swag, err := New("foo", "bar", "1.0.0", routers,
+			WithValidateOption("sku", func(fieldType reflect.Type, schema *openapi3.Schema, param string) error {
+				fieldTypes = append(fieldTypes, fieldType)
+
+				return skuOption(fieldType, schema, param)
+			}),
+		)
+		require.NoError(t, err)
+		require.NoError(t, swag.RegisterValidateOption("iban", func(_ reflect.Type, schema *openapi3.Schema, _ string) error {
+			schema.WithFormat("iban")
+
+			return nil
+		}))
+		properties := swag.OpenAPI.Paths["/products"].Post.RequestBody.Value.Content.Get("application/json").Schema.Value.Properties
+
+		assert.Equal(t, `^EU-[0-9]{6}$`, properties["sku"].Value.Pattern)
+		assert.Equal(t, `^[A-Z]{2}-[0-9]{6}$`, properties["aliases"].Value.Items.Value.Pattern)
+		assert.Equal(t, "iban", properties["account"].Value.Format)
+		assert.Contains(t, fieldTypes, reflect.TypeOf(""))
+	})
+
+	t.Run("Should report the path of the field in error", func(t *testing.T) {
+		_, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/products", http.MethodPost, nil, router.Model(struct {
+				SKU string `json:"sku" validate:"sku=JP"`
+			}{})),
+		}, WithValidateOption("sku", skuOption))
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errUnknownRegion))
+		assert.Equal(t, "SKU: sku=JP: unknown region", err.Error())
+	})
+
+	t.Run("Should report the path of a parameter in error", func(t *testing.T) {
+		_, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/products", http.MethodGet, nil, router.Model(ProductQuery{})),
+		}, WithValidateOption("tenant_id", func(fieldType reflect.Type, _ *openapi3.Schema, _ string) error {
+			return errors.Errorf("tenant_id expects an uuid, got %s", fieldType)
+		}))
+
+		require.Error(t, err)
+		assert.Equal(t, "swagger.ProductQuery.Tenant: tenant_id: tenant_id expects an uuid, got string", err.Error())
+	})
+
+	t.Run("Should report the path of a malformed built-in option", func(t *testing.T) {
+		_, err := New("foo", "bar", "1.0.0", []*router.Router{
+			router.New("/products", http.MethodGet, nil, router.Responses(router.ResponseMap{
+				"200": {Description: "OK", Model: struct {
+					Name string `json:"name" validate:"max=abc"`
+				}{}},
+			})),
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Name: Cannot parse max option")
+	})
+}
@@ -0,0 +1,150 @@
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+const (
+	mimeProblemJSON = "application/problem+json"
+	inBody          = "body"
+)
+
+// Problem is a RFC 7807 problem detail describing why a request was rejected
+type Problem struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError locates one validation error: a parameter by its name, a body field by its json pointer
+type ProblemError struct {
+	In      string `json:"in"`
+	Name    string `json:"name,omitempty"`
+	Pointer string `json:"pointer,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// RequestValidator returns a net/http middleware validating the path, query, header and cookie parameters
+// and the body of the documented routes. Invalid requests get a 400 problem response.
+// Undocumented routes go through untouched and the security requirements are not checked.
+func (swagger *Swagger) RequestValidator() (func(http.Handler) http.Handler, error) {
+	finder, err := swagger.routeFinder()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			route, pathParams, err := finder.FindRoute(request)
+			if err != nil {
+				next.ServeHTTP(writer, request)
+
+				return
+			}
+			//nolint:exhaustruct,nolintlint
+			input := &openapi3filter.RequestValidationInput{
+				Request:    request,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					MultiError:         true,
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(request.Context(), input); err != nil {
+				writeProblem(writer, newValidationProblem(err))
+
+				return
+			}
+			next.ServeHTTP(writer, request)
+		})
+	}, nil
+}
+
+// routeFinder matches the requests on the paths of the document whatever the servers are,
+// the application may be reached through a proxy or a test server
+func (swagger *Swagger) routeFinder() (routers.Router, error) {
+	document := *swagger.OpenAPI
+	document.Servers = nil
+
+	return gorillamux.NewRouter(&document)
+}
+
+func newValidationProblem(err error) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusBadRequest),
+		Status: http.StatusBadRequest,
+		Detail: "The request doesn't match the api specification.",
+		Errors: problemErrors(err),
+	}
+}
+
+// problemErrors flattens the errors of openapi3filter, the concrete types are matched
+// because a RequestError unwraps to the MultiError of its schema
+func problemErrors(err error) []ProblemError {
+	switch typedErr := err.(type) { //nolint:errorlint,nolintlint
+	case openapi3.MultiError:
+		var errs []ProblemError
+		for _, err := range typedErr {
+			errs = append(errs, problemErrors(err)...)
+		}
+
+		return errs
+	case *openapi3filter.RequestError:
+		problemError := ProblemError{In: inBody, Reason: typedErr.Error()}
+		if typedErr.Parameter != nil {
+			problemError.In = typedErr.Parameter.In
+			problemError.Name = typedErr.Parameter.Name
+		}
+		if typedErr.Err == nil {
+			return []ProblemError{problemError}
+		}
+		if typedErr.Reason != "" {
+			problemError.Reason = typedErr.Reason
+		}
+
+		return schemaProblemErrors(problemError, typedErr.Err)
+	default:
+		return []ProblemError{{Reason: err.Error()}}
+	}
+}
+
+// schemaProblemErrors details the schema errors of a parameter or a body
+func schemaProblemErrors(base ProblemError, err error) []ProblemError {
+	switch typedErr := err.(type) { //nolint:errorlint,nolintlint
+	case openapi3.MultiError:
+		var errs []ProblemError
+		for _, err := range typedErr {
+			errs = append(errs, schemaProblemErrors(base, err)...)
+		}
+
+		return errs
+	case *openapi3.SchemaError:
+		base.Reason = typedErr.Reason
+		if base.In == inBody {
+			base.Pointer = "/" + strings.Join(typedErr.JSONPointer(), "/")
+		}
+
+		return []ProblemError{base}
+	default:
+		base.Reason = err.Error()
+
+		return []ProblemError{base}
+	}
+}
+
+func writeProblem(writer http.ResponseWriter, problem *Problem) {
+	writer.Header().Set(contentTypeHeader, mimeProblemJSON)
+	writer.WriteHeader(problem.Status)
+	_ = json.NewEncoder(writer).Encode(problem)
+}
@@ -0,0 +1,90 @@
+//nolint:exhaustruct, nolintlint
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/guiyomh/swagger/pkg/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger_RequestValidator(t *testing.T) {
+	type PetModel struct {
+		ID    int    `uri:"id"`
+		Limit int    `query:"limit" validate:"required,max=100"`
+		Token string `header:"X-Token" validate:"required"`
+		Name  string `json:"name" validate:"required"`
+		Age   int    `json:"age"`
+	}
+	swag, err := New("foo", "bar", "1.0.0", []*router.Router{
+		router.New("/pets/:id", http.MethodPut, nil,
+			router.Model(new(PetModel)),
+			router.Responses(router.ResponseMap{"204": {Description: "updated"}}),
+		),
+	})
+	require.NoError(t, err)
+	middleware, err := swag.RequestValidator()
+	require.NoError(t, err)
+	handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+
+	t.Run("Should let a valid request through", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, "/pets/12?limit=10", strings.NewReader(`{"name":"rex","age":3}`))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Token", "secret")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, request)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Should let a body without the optional fields through", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, "/pets/12?limit=10", strings.NewReader(`{"name":"rex"}`))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Token", "secret")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, request)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Should let an undocumented route through", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Should reject an invalid request with a problem", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPut, "/pets/abc?limit=1000", strings.NewReader(`{"age":"old"}`))
+		request.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, request)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		var problem Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		located := map[string]bool{}
+		for _, problemError := range problem.Errors {
+			assert.NotEmpty(t, problemError.Reason)
+			located[problemError.In+":"+problemError.Name+problemError.Pointer] = true
+		}
+		assert.True(t, located["path:id"], problem.Errors)
+		assert.True(t, located["query:limit"], problem.Errors)
+		assert.True(t, located["header:X-Token"], problem.Errors)
+		assert.True(t, located["body:/name"], problem.Errors)
+		assert.True(t, located["body:/age"], problem.Errors)
+	})
+}